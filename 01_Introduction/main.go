@@ -1,113 +1,109 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"sort"
-)
-
-// Food Module
-type Food struct {
-	name     string
-	value    float64
-	calories float64
-}
-
-func (f Food) getValue() float64 { return f.value }
-
-func (f Food) getCost() float64 { return f.calories }
-
-func (f Food) density() float64 { return f.value / f.calories }
-
-func (f Food) String() string {
-	return fmt.Sprintf("%s: <%.0f, %.0f>", f.name, f.value, f.calories)
-}
-
-// names, vallues, calories list the same length.
-// name a list of strings
-// values and calories lists of numbers
-// return list of Foods
-func buildMenu(names []string, values []float64, calories []float64) []Food {
-	var menu []Food
-	for i, v := range values {
-		var f Food
-		f.name = names[i]
-		f.value = v
-		f.calories = calories[i]
-		menu = append(menu, f)
-	}
-
-	return menu
-}
-
-type customSort struct {
-	t    []Food
-	less func(x, y Food) bool
-}
-
-func (x customSort) Len() int           { return len(x.t) }
-func (x customSort) Less(i, j int) bool { return x.less(x.t[i], x.t[j]) }
-func (x customSort) Swap(i, j int)      { x.t[i], x.t[j] = x.t[j], x.t[i] }
-
-type CompFunction func(x, y Food) bool
-
-func greedy(items []Food, maxCost float64, compFunc CompFunction) ([]Food, float64) {
-	itemsCopy := items[:]
-
-	sort.Sort(customSort{itemsCopy, compFunc})
-	//fmt.Println(itemsCopy)
-
-	var result []Food
-	totalValue := 0.0
-	totalCost := 0.0
-
-	for _, item := range itemsCopy {
-		if (totalCost + item.getCost()) <= maxCost {
-			result = append(result, item)
-			totalCost += item.getCost()
-			totalValue += item.getValue()
-		}
-	}
-	//fmt.Println("total cost=", totalCost)
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
 
-	return result, totalValue
-}
+	"../02_Optimization/menu"
+)
 
-func testGreedy(items []Food, constraint float64, compFunc CompFunction) {
-	taken, val := greedy(items, constraint, compFunc)
+func testGreedy(items []menu.Food, constraint float64, compFunc menu.CompFunction) {
+	taken, val := menu.Greedy(items, menu.CalorieBudget(constraint), compFunc)
 	fmt.Println("Total value of items taken =", val)
 	for _, item := range taken {
 		fmt.Println("   ", item)
 	}
 }
 
-func testGreedys(foods []Food, maxUnits float64) {
+func testGreedys(foods []menu.Food, maxUnits float64) {
 	fmt.Println("Use greedy by value to allocate", maxUnits, "calories")
-	testGreedy(foods, maxUnits, func(x, y Food) bool {
-		return x.value > y.value
-	})
+	testGreedy(foods, maxUnits, menu.ByValue)
 	fmt.Println()
 
 	fmt.Println("Use greedy by cost to allocate", maxUnits, "calories")
-	testGreedy(foods, maxUnits, func(x, y Food) bool {
-		return x.calories < y.calories
-	})
+	testGreedy(foods, maxUnits, menu.ByCost)
 	fmt.Println()
 
 	fmt.Println("Use greedy by density to allocate", maxUnits, "calories")
-	testGreedy(foods, maxUnits, func(x, y Food) bool {
-		return x.density() > y.density()
-	})
+	testGreedy(foods, maxUnits, menu.ByDensity)
 	fmt.Println()
 }
 
+// parseBudgets reads calorie budgets written as from:to:step.
+func parseBudgets(s string) ([]menu.Budget, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("budgets %q: want from:to:step", s)
+	}
+	var r [3]float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, fmt.Errorf("budgets %q: %v", s, err)
+		}
+		r[i] = v
+	}
+	if r[2] <= 0 {
+		return nil, fmt.Errorf("budgets %q: step must be positive", s)
+	}
+	// Count the steps rather than adding up the step, so that rounding
+	// cannot drop the last budget, as adding 0.1 ten times to 0 would.
+	n := math.Floor((r[1]-r[0])/r[2]*(1+1e-12)) + 1
+	var budgets []menu.Budget
+	for i := 0; i < int(n); i++ {
+		budgets = append(budgets, menu.CalorieBudget(math.Min(r[0]+float64(i)*r[2], r[1])))
+	}
+	return budgets, nil
+}
+
+func reportGreedys(foods []menu.Food, budgets []menu.Budget) {
+	reports, err := menu.GreedySweep(foods, budgets)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	for _, r := range reports {
+		r.Write(os.Stdout)
+		fmt.Println()
+	}
+	menu.WriteSweepSummary(os.Stdout, reports)
+}
+
 func main() {
-	names := [...]string{"wine", "beer", "pizza", "burger", "fries", "cola", "apple", "donut", "cake"}
-	values := [...]float64{89, 90, 95, 100, 90, 79, 50, 10}
-	calories := [...]float64{123, 154, 258, 354, 365, 150, 95, 195}
-	foods := buildMenu(names[:], values[:], calories[:])
+	menuPath := flag.String("menu", "", "read the menu from a .csv or .json file")
+	report := flag.Bool("report", false, "compare each greedy with the optimum over a sweep of budgets")
+	budgetRange := flag.String("budgets", "100:1500:100", "calorie budgets of the report, as from:to:step")
+	flag.Parse()
+
+	var foods []menu.Food
+	var err error
+	if *menuPath != "" {
+		foods, err = menu.Load(*menuPath)
+	} else {
+		names := [...]string{"wine", "beer", "pizza", "burger", "fries", "cola", "apple", "donut"}
+		values := [...]float64{89, 90, 95, 100, 90, 79, 50, 10}
+		calories := [...]float64{123, 154, 258, 354, 365, 150, 95, 195}
+		foods, err = menu.BuildMenu(names[:], values[:], calories[:])
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
 	fmt.Println("menu =", foods)
 	fmt.Println()
 
+	if *report {
+		budgets, err := parseBudgets(*budgetRange)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		reportGreedys(foods, budgets)
+		return
+	}
+
 	testGreedys(foods, 750.0)
 	//testGreedys(foods, 800.0)
 	//testGreedys(foods, 1000.0)
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"../menu"
+)
+
+// source makes a menu to try the greedy strategies on.
+type source struct {
+	name string
+	make func(r *rand.Rand, n int) ([]menu.Food, menu.Budget)
+}
+
+// sources are random menus with random budgets, and menus of every family
+// of generated instances with half of their total calories as budget.
+func sources(R int) []source {
+	list := []source{{"random", func(r *rand.Rand, n int) ([]menu.Food, menu.Budget) {
+		return menu.RandomMenu(r, n, R)
+	}}}
+	for _, family := range menu.Families() {
+		family := family
+		list = append(list, source{family.String(), func(r *rand.Rand, n int) ([]menu.Food, menu.Budget) {
+			items, err := menu.Generate(family, n, R, r.Int63())
+			if err != nil {
+				log.Fatalln(err)
+			}
+			return items, menu.HalfCapacity(items)
+		}})
+	}
+	return list
+}
+
+// search keeps the keep worst counterexamples of each strategy among trials
+// menus of 2 to maxItems foods from every source.
+func search(r *rand.Rand, trials, maxItems, R, keep int) map[string]*menu.Worst {
+	worst := make(map[string]*menu.Worst)
+	for _, s := range menu.GreedyStrategies {
+		worst[s.Name] = &menu.Worst{K: keep}
+	}
+	for t := 0; t < trials; t++ {
+		for _, src := range sources(R) {
+			items, budget := src.make(r, 2+r.Intn(maxItems-1))
+			for _, s := range menu.GreedyStrategies {
+				c, err := menu.Compare(s, src.name, items, budget)
+				if err != nil {
+					log.Fatalln(err)
+				}
+				worst[s.Name].Offer(c)
+			}
+		}
+	}
+	return worst
+}
+
+func main() {
+	trials := flag.Int("trials", 2000, "menus to try from each source")
+	maxItems := flag.Int("n", 8, "most foods on a menu")
+	R := flag.Int("range", 20, "largest value and calories of a food")
+	keep := flag.Int("keep", 3, "worst counterexamples to keep for each strategy")
+	seed := flag.Int64("seed", 1, "seed of the menus")
+	out := flag.String("out", "counterexamples", "directory to save the worst menus and their budgets in")
+	flag.Parse()
+	if *maxItems < 2 || *R < 1 {
+		log.Fatalln("want -n of at least 2 and a positive -range")
+	}
+
+	fmt.Println("seed =", *seed)
+	worst := search(rand.New(rand.NewSource(*seed)), *trials, *maxItems, *R, *keep)
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		log.Fatalln(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "strategy\tsource\tfoods\tbudget\tgreedy\toptimum\tratio\tfile")
+	smallest := make(map[string]menu.Counterexample)
+	for _, s := range menu.GreedyStrategies {
+		for rank, c := range worst[s.Name].Cases {
+			found := len(c.Menu)
+			c, err := menu.Minimize(c, s)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			if rank == 0 {
+				smallest[s.Name] = c
+			}
+			base := filepath.Join(*out, fmt.Sprintf("%s-%d", s.Name, rank+1))
+			file := base + ".csv"
+			if err := menu.Save(file, c.Menu); err != nil {
+				log.Fatalln(err)
+			}
+			if err := menu.SaveBudget(base+".budget", c.Budget); err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d of %d\t%v\t%g\t%g\t%.1f%%\t%s\n",
+				s.Name, c.Source, len(c.Menu), found, c.Budget, c.Greedy, c.Optimum, 100*c.Ratio(), file)
+		}
+	}
+	tw.Flush()
+
+	fmt.Println()
+	for _, s := range menu.GreedyStrategies {
+		c, ok := smallest[s.Name]
+		if !ok {
+			fmt.Printf("greedy by %s was never beaten\n", s.Name)
+			continue
+		}
+		taken, _ := menu.Greedy(c.Menu, c.Budget, s.Order(c.Budget))
+		opt, err := menu.SearchTree{}.Solve(c.Menu, c.Budget)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("Worst for greedy by %s, within %v:\n", s.Name, c.Budget)
+		fmt.Printf("    menu    %v\n", c.Menu)
+		fmt.Printf("    greedy  %s\n", names(taken))
+		fmt.Printf("    optimum %s\n", names(opt.Items))
+	}
+}
+
+func names(items []menu.Food) string {
+	var list []string
+	for _, item := range items {
+		list = append(list, item.Name())
+	}
+	return strings.Join(list, " ")
+}
@@ -0,0 +1,294 @@
+// Package diet solves the diet problem on the menus of package menu: the
+// cheapest servings of foods whose nutrients all stay within bounds. The
+// nutrients of a food are its calories and the extra columns of its menu,
+// read with Food.CostOf.
+package diet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"../menu"
+)
+
+var (
+	ErrMissingNutrient = errors.New("diet: food has no column")
+	ErrBadBound        = errors.New("diet: bad bound")
+	ErrNodeLimit       = errors.New("diet: branch and bound node limit reached")
+)
+
+// Bound is the least and the most of a nutrient a diet may have. A Max of
+// +Inf is no upper bound.
+type Bound struct {
+	Nutrient string
+	Min, Max float64
+}
+
+func (b Bound) String() string {
+	switch {
+	case math.IsInf(b.Max, 1):
+		return fmt.Sprintf("%s >= %g", b.Nutrient, b.Min)
+	case b.Min == 0:
+		return fmt.Sprintf("%s <= %g", b.Nutrient, b.Max)
+	}
+	return fmt.Sprintf("%g <= %s <= %g", b.Min, b.Nutrient, b.Max)
+}
+
+// ParseBounds reads bounds written as "calories=2000:2500,protein=50:,
+// sodium=:2300", either side of the colon left empty for no bound.
+func ParseBounds(s string) ([]Bound, error) {
+	var bounds []Bound
+	for _, part := range strings.Split(s, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		lo, hi, ok2 := strings.Cut(val, ":")
+		if !ok || !ok2 {
+			return nil, fmt.Errorf("%w %q: want nutrient=min:max", ErrBadBound, part)
+		}
+		b := Bound{Nutrient: strings.ToLower(strings.TrimSpace(key)), Max: math.Inf(1)}
+		var err error
+		if lo = strings.TrimSpace(lo); lo != "" {
+			if b.Min, err = strconv.ParseFloat(lo, 64); err != nil {
+				return nil, fmt.Errorf("%w %q: %v", ErrBadBound, part, err)
+			}
+		}
+		if hi = strings.TrimSpace(hi); hi != "" {
+			if b.Max, err = strconv.ParseFloat(hi, 64); err != nil {
+				return nil, fmt.Errorf("%w %q: %v", ErrBadBound, part, err)
+			}
+		}
+		bounds = append(bounds, b)
+	}
+	return bounds, nil
+}
+
+// Problem asks for the servings of Foods that meet every one of Bounds at
+// the least total Cost, the column giving the cost of a serving, such as
+// "price". A food with a "max" column is limited to that many servings.
+type Problem struct {
+	Foods  []menu.Food
+	Cost   string
+	Bounds []Bound
+}
+
+// Diet is a solution of a Problem: how many servings of each food, with
+// the total cost and the total of each bounded nutrient.
+type Diet struct {
+	Foods     []menu.Food
+	Servings  []float64
+	Cost      float64
+	Nutrients map[string]float64
+}
+
+func (d Diet) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "cost=%.2f", d.Cost)
+	for _, n := range menu.Budget(d.Nutrients).Dims() {
+		fmt.Fprintf(&b, ", %s=%.4g", n, d.Nutrients[n])
+	}
+	for i, f := range d.Foods {
+		fmt.Fprintf(&b, "\n    %.4g x %s", d.Servings[i], f.Name())
+	}
+	return b.String()
+}
+
+// lp is the linear program of p: a variable per food, a row per finite side
+// of each bound and one per food with a "max" column.
+func (p Problem) lp() (LP, error) {
+	lp := LP{Cost: make([]float64, len(p.Foods))}
+	for i, f := range p.Foods {
+		c, ok := f.CostOf(p.Cost)
+		if !ok {
+			return lp, fmt.Errorf("%w %s: %s", ErrMissingNutrient, p.Cost, f.Name())
+		}
+		lp.Cost[i] = c
+	}
+	for _, b := range p.Bounds {
+		if b.Min > b.Max || b.Min < 0 {
+			return lp, fmt.Errorf("%w: %v", ErrBadBound, b)
+		}
+		coeffs := make([]float64, len(p.Foods))
+		for i, f := range p.Foods {
+			v, ok := f.CostOf(b.Nutrient)
+			if !ok {
+				return lp, fmt.Errorf("%w %s: %s", ErrMissingNutrient, b.Nutrient, f.Name())
+			}
+			coeffs[i] = v
+		}
+		if b.Min > 0 {
+			lp.Constraints = append(lp.Constraints, Constraint{coeffs, GreaterEq, b.Min})
+		}
+		if !math.IsInf(b.Max, 1) {
+			lp.Constraints = append(lp.Constraints, Constraint{coeffs, LessEq, b.Max})
+		}
+	}
+	for i, f := range p.Foods {
+		if q, ok := f.Attr(menu.MaxQuantity); ok {
+			lp.Constraints = append(lp.Constraints, unit(len(p.Foods), i, LessEq, q))
+		}
+	}
+	return lp, nil
+}
+
+// unit is the constraint x[i] sense rhs.
+func unit(n, i int, sense Sense, rhs float64) Constraint {
+	coeffs := make([]float64, n)
+	coeffs[i] = 1
+	return Constraint{coeffs, sense, rhs}
+}
+
+func (p Problem) diet(x []float64) Diet {
+	d := Diet{Nutrients: make(map[string]float64)}
+	for _, b := range p.Bounds {
+		d.Nutrients[b.Nutrient] = 0
+	}
+	for i, f := range p.Foods {
+		if x[i] <= eps {
+			continue
+		}
+		d.Foods = append(d.Foods, f)
+		d.Servings = append(d.Servings, x[i])
+		c, _ := f.CostOf(p.Cost)
+		d.Cost += x[i] * c
+		for n := range d.Nutrients {
+			v, _ := f.CostOf(n)
+			d.Nutrients[n] += x[i] * v
+		}
+	}
+	return d
+}
+
+// explain says which bounds make p infeasible: those that no diet meets
+// even alone, or else all of them together.
+func (p Problem) explain() error {
+	var alone []string
+	for _, b := range p.Bounds {
+		q := Problem{p.Foods, p.Cost, []Bound{b}}
+		lp, err := q.lp()
+		if err != nil {
+			return err
+		}
+		if _, _, err := lp.Minimize(); errors.Is(err, ErrInfeasible) {
+			alone = append(alone, b.String())
+		}
+	}
+	if len(alone) > 0 {
+		return fmt.Errorf("%w: no diet meets %s", ErrInfeasible, strings.Join(alone, ", nor "))
+	}
+	var all []string
+	for _, b := range p.Bounds {
+		all = append(all, b.String())
+	}
+	return fmt.Errorf("%w: the bounds %s cannot all be met at once", ErrInfeasible, strings.Join(all, ", "))
+}
+
+// Solve finds the cheapest diet in any fractions of servings.
+func (p Problem) Solve() (Diet, error) {
+	lp, err := p.lp()
+	if err != nil {
+		return Diet{}, err
+	}
+	x, _, err := lp.Minimize()
+	if errors.Is(err, ErrInfeasible) {
+		return Diet{}, p.explain()
+	}
+	if err != nil {
+		return Diet{}, err
+	}
+	return p.diet(x), nil
+}
+
+// SolveWhole finds the cheapest diet in whole servings by branch and bound:
+// it solves the linear program, and on a fractional serving x of some food
+// tries again with at most floor(x) and with at least ceil(x) servings of
+// it, skipping branches that cannot beat the best diet found. The servings
+// of the linear program rounded up, when they meet the bounds, are the
+// first such diet. At most maxNodes programs are solved.
+func (p Problem) SolveWhole(maxNodes int) (Diet, error) {
+	root, err := p.lp()
+	if err != nil {
+		return Diet{}, err
+	}
+	x, _, err := root.Minimize()
+	if errors.Is(err, ErrInfeasible) {
+		return Diet{}, p.explain()
+	}
+	if err != nil {
+		return Diet{}, err
+	}
+
+	var best []float64
+	bestCost := math.Inf(1)
+	up := make([]float64, len(x))
+	for i, v := range x {
+		up[i] = math.Ceil(v - eps)
+	}
+	if feasible(root, up) {
+		best, bestCost = up, dot(root.Cost, up)
+	}
+
+	stack := [][]Constraint{nil}
+	for nodes := 0; len(stack) > 0; nodes++ {
+		if nodes == maxNodes {
+			return Diet{}, fmt.Errorf("%w after %d programs", ErrNodeLimit, maxNodes)
+		}
+		extra := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		lp := LP{root.Cost, append(root.Constraints[:len(root.Constraints):len(root.Constraints)], extra...)}
+		x, cost, err := lp.Minimize()
+		if errors.Is(err, ErrInfeasible) || err == nil && cost >= bestCost-eps {
+			continue
+		}
+		if err != nil {
+			return Diet{}, err
+		}
+		j, frac := -1, 0.0
+		for i, v := range x {
+			if f := math.Abs(v - math.Round(v)); f > 1e-6 && f > frac {
+				j, frac = i, f
+			}
+		}
+		if j < 0 {
+			best, bestCost = x, cost
+			for i := range best {
+				best[i] = math.Round(best[i])
+			}
+			continue
+		}
+		n := len(extra)
+		down := append(extra[:n:n], unit(len(x), j, LessEq, math.Floor(x[j])))
+		upper := append(extra[:n:n], unit(len(x), j, GreaterEq, math.Ceil(x[j])))
+		// Search the nearer side first.
+		if x[j]-math.Floor(x[j]) < 0.5 {
+			stack = append(stack, upper, down)
+		} else {
+			stack = append(stack, down, upper)
+		}
+	}
+	if best == nil {
+		return Diet{}, fmt.Errorf("%w in whole servings", ErrInfeasible)
+	}
+	return p.diet(best), nil
+}
+
+func dot(a, b []float64) float64 {
+	total := 0.0
+	for i := range a {
+		total += a[i] * b[i]
+	}
+	return total
+}
+
+// feasible reports whether x meets every constraint of lp.
+func feasible(lp LP, x []float64) bool {
+	for _, c := range lp.Constraints {
+		v := dot(c.Coeffs, x)
+		tol := 1e-7 * math.Max(1, math.Abs(c.RHS))
+		if c.Sense != GreaterEq && v > c.RHS+tol || c.Sense != LessEq && v < c.RHS-tol {
+			return false
+		}
+	}
+	return true
+}
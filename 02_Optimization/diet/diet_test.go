@@ -0,0 +1,181 @@
+package diet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"../menu"
+)
+
+// food has the given price and protein, and calories as its menu cost. A
+// max of 0 leaves out the max column.
+func food(name string, calories, price, protein, max float64) menu.Food {
+	var f menu.Food
+	f.Init(name, 0, calories)
+	f.SetAttr("price", price)
+	f.SetAttr("protein", protein)
+	if max > 0 {
+		f.SetAttr(menu.MaxQuantity, max)
+	}
+	return f
+}
+
+func TestParseBounds(t *testing.T) {
+	got, err := ParseBounds("Calories=2000:2500, protein=50:,sodium=:2300")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Bound{
+		{"calories", 2000, 2500},
+		{"protein", 50, math.Inf(1)},
+		{"sodium", 0, 2300},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseBounds() = %v, want %v", got, want)
+	}
+	for _, s := range []string{"protein", "protein=50", "protein=x:", "protein=:y"} {
+		if _, err := ParseBounds(s); !errors.Is(err, ErrBadBound) {
+			t.Errorf("ParseBounds(%q): got %v, want ErrBadBound", s, err)
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+	p := Problem{
+		Foods: []menu.Food{
+			food("beans", 100, 1, 10, 0),
+			food("steak", 300, 5, 40, 0),
+		},
+		Cost:   "price",
+		Bounds: []Bound{{"protein", 50, math.Inf(1)}, {"calories", 0, 450}},
+	}
+	// Beans alone are cheaper for protein but too many calories: 3 beans
+	// and 0.5 steak meet both bounds exactly.
+	d, err := p.Solve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(d.Cost-5.5) > 1e-9 || math.Abs(d.Nutrients["protein"]-50) > 1e-9 || math.Abs(d.Nutrients["calories"]-450) > 1e-9 {
+		t.Errorf("Solve() = %v, want cost 5.5 at protein 50 and calories 450", d)
+	}
+}
+
+func TestSolveErrors(t *testing.T) {
+	foods := []menu.Food{food("beans", 100, 1, 10, 2)}
+	for _, c := range []struct {
+		p    Problem
+		want error
+	}{
+		{Problem{foods, "salt", nil}, ErrMissingNutrient},
+		{Problem{foods, "price", []Bound{{"fat", 0, 1}}}, ErrMissingNutrient},
+		{Problem{foods, "price", []Bound{{"protein", 5, 1}}}, ErrBadBound},
+		{Problem{foods, "price", []Bound{{"protein", -1, 1}}}, ErrBadBound},
+		// At most 2 servings of 10 protein.
+		{Problem{foods, "price", []Bound{{"protein", 30, math.Inf(1)}}}, ErrInfeasible},
+	} {
+		if _, err := c.p.Solve(); !errors.Is(err, c.want) {
+			t.Errorf("%v: got %v, want %v", c.p.Bounds, err, c.want)
+		}
+	}
+}
+
+func TestSolveNamesInfeasibleBounds(t *testing.T) {
+	p := Problem{
+		Foods:  []menu.Food{food("beans", 100, 1, 10, 0)},
+		Cost:   "price",
+		Bounds: []Bound{{"protein", 50, math.Inf(1)}, {"calories", 0, 300}},
+	}
+	_, err := p.Solve()
+	want := "diet: no solution meets every constraint: the bounds protein >= 50, calories <= 300 cannot all be met at once"
+	if !errors.Is(err, ErrInfeasible) || err.Error() != want {
+		t.Errorf("got %v, want %q", err, want)
+	}
+}
+
+// bruteWhole is the least cost of whole servings of p's foods, each up to
+// its max column, that meet p's bounds, +Inf if none do.
+func bruteWhole(p Problem) float64 {
+	best := math.Inf(1)
+	x := make([]float64, len(p.Foods))
+	var try func(i int)
+	try = func(i int) {
+		if i == len(p.Foods) {
+			cost := 0.0
+			for j, f := range p.Foods {
+				c, _ := f.CostOf(p.Cost)
+				cost += x[j] * c
+			}
+			for _, b := range p.Bounds {
+				total := 0.0
+				for j, f := range p.Foods {
+					v, _ := f.CostOf(b.Nutrient)
+					total += x[j] * v
+				}
+				if total < b.Min || total > b.Max {
+					return
+				}
+			}
+			best = math.Min(best, cost)
+			return
+		}
+		max, _ := p.Foods[i].Attr(menu.MaxQuantity)
+		for x[i] = 0; x[i] <= max; x[i]++ {
+			try(i + 1)
+		}
+	}
+	try(0)
+	return best
+}
+
+func TestSolveWholeMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for k := 0; k < 300; k++ {
+		var foods []menu.Food
+		for i := 0; i < 2+r.Intn(3); i++ {
+			foods = append(foods, food(fmt.Sprint("f", i),
+				float64(50+r.Intn(400)), float64(1+r.Intn(10)), float64(r.Intn(30)), float64(1+r.Intn(4))))
+		}
+		p := Problem{
+			Foods: foods,
+			Cost:  "price",
+			Bounds: []Bound{
+				{"protein", float64(r.Intn(60)), math.Inf(1)},
+				{"calories", 0, float64(300 + r.Intn(1500))},
+			},
+		}
+		want := bruteWhole(p)
+		d, err := p.SolveWhole(100000)
+		if math.IsInf(want, 1) {
+			if !errors.Is(err, ErrInfeasible) {
+				t.Fatalf("%v %v: got %v, %v, want ErrInfeasible", foods, p.Bounds, d, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%v %v: %v", foods, p.Bounds, err)
+		}
+		for _, s := range d.Servings {
+			if s != math.Round(s) {
+				t.Fatalf("%v %v: %v has a fractional serving", foods, p.Bounds, d)
+			}
+		}
+		if math.Abs(d.Cost-want) > 1e-9 {
+			t.Fatalf("%v %v: SolveWhole() = %v, want cost %v", foods, p.Bounds, d, want)
+		}
+	}
+}
+
+func TestSolveWholeNodeLimit(t *testing.T) {
+	p := Problem{
+		Foods:  []menu.Food{food("a", 100, 3, 7, 0), food("b", 100, 5, 11, 0)},
+		Cost:   "price",
+		Bounds: []Bound{{"protein", 100, math.Inf(1)}},
+	}
+	if _, err := p.SolveWhole(1); !errors.Is(err, ErrNodeLimit) {
+		t.Errorf("got %v, want ErrNodeLimit", err)
+	}
+}
@@ -0,0 +1,215 @@
+package diet
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrInfeasible = errors.New("diet: no solution meets every constraint")
+	ErrUnbounded  = errors.New("diet: cost has no lower bound")
+)
+
+// eps is the tolerance of the simplex method for treating a number as zero.
+const eps = 1e-9
+
+// Sense is the relation between the two sides of a Constraint.
+type Sense int
+
+const (
+	LessEq Sense = iota
+	GreaterEq
+	Equal
+)
+
+// Constraint is Coeffs·x Sense RHS.
+type Constraint struct {
+	Coeffs []float64
+	Sense  Sense
+	RHS    float64
+}
+
+// LP is the linear program of minimizing Cost·x subject to Constraints
+// and x >= 0.
+type LP struct {
+	Cost        []float64
+	Constraints []Constraint
+}
+
+// tableau is the simplex tableau: rows[i] is the equation of basic
+// variable basis[i], with the right hand side in the last column, and obj
+// is the objective row of reduced costs with minus its value last.
+type tableau struct {
+	rows  [][]float64
+	obj   []float64
+	basis []int
+}
+
+func (t *tableau) pivot(r, c int) {
+	row := t.rows[r]
+	p := row[c]
+	for j := range row {
+		row[j] /= p
+	}
+	eliminate := func(other []float64) {
+		f := other[c]
+		if f == 0 {
+			return
+		}
+		for j := range other {
+			other[j] -= f * row[j]
+		}
+	}
+	for i, other := range t.rows {
+		if i != r {
+			eliminate(other)
+		}
+	}
+	eliminate(t.obj)
+	t.basis[r] = c
+}
+
+// run pivots until no column among the first cols improves the objective,
+// entering the lowest such column and leaving by the ratio test with ties
+// to the lowest basic variable, Bland's rule, so it cannot cycle.
+func (t *tableau) run(cols int) error {
+	last := len(t.obj) - 1
+	for {
+		c := -1
+		for j := 0; j < cols; j++ {
+			if t.obj[j] < -eps {
+				c = j
+				break
+			}
+		}
+		if c < 0 {
+			return nil
+		}
+		r := -1
+		best := math.Inf(1)
+		for i, row := range t.rows {
+			if row[c] > eps {
+				ratio := row[last] / row[c]
+				if r < 0 || ratio < best-eps || math.Abs(ratio-best) <= eps && t.basis[i] < t.basis[r] {
+					r, best = i, ratio
+				}
+			}
+		}
+		if r < 0 {
+			return ErrUnbounded
+		}
+		t.pivot(r, c)
+	}
+}
+
+// Minimize solves lp with the two-phase simplex method and returns x and
+// its cost, ErrInfeasible if no x meets the constraints or ErrUnbounded if
+// the cost can be made as low as wanted.
+func (lp LP) Minimize() ([]float64, float64, error) {
+	n, m := len(lp.Cost), len(lp.Constraints)
+
+	// Columns: the n variables, a slack or surplus for each inequality,
+	// then an artificial variable for each row that needs one.
+	slacks, artificials := 0, 0
+	cons := make([]Constraint, m)
+	for i, c := range lp.Constraints {
+		if c.RHS < 0 {
+			neg := make([]float64, len(c.Coeffs))
+			for j, a := range c.Coeffs {
+				neg[j] = -a
+			}
+			c = Constraint{neg, [...]Sense{GreaterEq, LessEq, Equal}[c.Sense], -c.RHS}
+		}
+		cons[i] = c
+		if c.Sense != Equal {
+			slacks++
+		}
+		if c.Sense != LessEq {
+			artificials++
+		}
+	}
+	width := n + slacks + artificials + 1
+	t := &tableau{obj: make([]float64, width), basis: make([]int, m)}
+	slack, art := n, n+slacks
+	for i, c := range cons {
+		row := make([]float64, width)
+		copy(row, c.Coeffs)
+		row[width-1] = c.RHS
+		switch c.Sense {
+		case LessEq:
+			row[slack] = 1
+			t.basis[i] = slack
+			slack++
+		case GreaterEq:
+			row[slack] = -1
+			slack++
+			fallthrough
+		case Equal:
+			row[art] = 1
+			t.basis[i] = art
+			art++
+		}
+		t.rows = append(t.rows, row)
+	}
+
+	// Phase one: minimize the sum of the artificial variables.
+	for i, row := range t.rows {
+		if t.basis[i] >= n+slacks {
+			for j := range t.obj {
+				t.obj[j] -= row[j]
+			}
+			t.obj[t.basis[i]] = 0
+		}
+	}
+	if err := t.run(n + slacks + artificials); err != nil {
+		return nil, 0, err
+	}
+	if -t.obj[width-1] > eps*math.Max(1, float64(m)) {
+		return nil, 0, ErrInfeasible
+	}
+	// Pivot the artificial variables left in the basis out of it, or
+	// drop their rows if they are redundant.
+	for i := 0; i < len(t.rows); i++ {
+		if t.basis[i] < n+slacks {
+			continue
+		}
+		c := -1
+		for j := 0; j < n+slacks; j++ {
+			if math.Abs(t.rows[i][j]) > eps {
+				c = j
+				break
+			}
+		}
+		if c < 0 {
+			t.rows = append(t.rows[:i], t.rows[i+1:]...)
+			t.basis = append(t.basis[:i], t.basis[i+1:]...)
+			i--
+			continue
+		}
+		t.pivot(i, c)
+	}
+
+	// Phase two: the real cost, over the variables and slacks only.
+	for j := range t.obj {
+		t.obj[j] = 0
+	}
+	copy(t.obj, lp.Cost)
+	for i, row := range t.rows {
+		if f := t.obj[t.basis[i]]; f != 0 {
+			for j := range t.obj {
+				t.obj[j] -= f * row[j]
+			}
+		}
+	}
+	if err := t.run(n + slacks); err != nil {
+		return nil, 0, err
+	}
+
+	x := make([]float64, n)
+	for i, b := range t.basis {
+		if b < n {
+			x[b] = t.rows[i][width-1]
+		}
+	}
+	return x, -t.obj[width-1], nil
+}
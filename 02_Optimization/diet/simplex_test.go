@@ -0,0 +1,64 @@
+package diet
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMinimize(t *testing.T) {
+	// min 2x + 3y with x + y >= 4, x + 3y >= 6 and x <= 5: x=3, y=1.
+	lp := LP{
+		Cost: []float64{2, 3},
+		Constraints: []Constraint{
+			{[]float64{1, 1}, GreaterEq, 4},
+			{[]float64{1, 3}, GreaterEq, 6},
+			{[]float64{1, 0}, LessEq, 5},
+		},
+	}
+	x, cost, err := lp.Minimize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(x[0]-3) > 1e-9 || math.Abs(x[1]-1) > 1e-9 || math.Abs(cost-9) > 1e-9 {
+		t.Errorf("Minimize() = %v, %v, want [3 1], 9", x, cost)
+	}
+}
+
+func TestMinimizeEqualAndNegativeRHS(t *testing.T) {
+	// min x - y with x + y = 2 and -x <= -0.5: x=0.5, y=1.5.
+	lp := LP{
+		Cost: []float64{1, -1},
+		Constraints: []Constraint{
+			{[]float64{1, 1}, Equal, 2},
+			{[]float64{-1, 0}, LessEq, -0.5},
+		},
+	}
+	x, cost, err := lp.Minimize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(x[0]-0.5) > 1e-9 || math.Abs(x[1]-1.5) > 1e-9 || math.Abs(cost+1) > 1e-9 {
+		t.Errorf("Minimize() = %v, %v, want [0.5 1.5], -1", x, cost)
+	}
+}
+
+func TestMinimizeErrors(t *testing.T) {
+	infeasible := LP{
+		Cost: []float64{1},
+		Constraints: []Constraint{
+			{[]float64{1}, LessEq, 1},
+			{[]float64{1}, GreaterEq, 2},
+		},
+	}
+	if _, _, err := infeasible.Minimize(); !errors.Is(err, ErrInfeasible) {
+		t.Errorf("x <= 1, x >= 2: got %v, want ErrInfeasible", err)
+	}
+	unbounded := LP{
+		Cost:        []float64{-1, 0},
+		Constraints: []Constraint{{[]float64{0, 1}, LessEq, 1}},
+	}
+	if _, _, err := unbounded.Minimize(); !errors.Is(err, ErrUnbounded) {
+		t.Errorf("min -x: got %v, want ErrUnbounded", err)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"../diet"
+	"../menu"
+)
+
+func main() {
+	menuPath := flag.String("menu", "../nutrients.csv", "menu with a cost column and nutrient columns")
+	cost := flag.String("cost", "price", "column to minimize")
+	boundsFlag := flag.String("bounds", "calories=2000:2500,protein=56:,fat=:78,carbs=130:,sodium=:2300",
+		"nutrient bounds, e.g. protein=56:,sodium=:2300")
+	whole := flag.Bool("whole", false, "only whole servings")
+	maxNodes := flag.Int("nodes", 100000, "most linear programs solved for whole servings")
+	flag.Parse()
+
+	foods, err := menu.Load(*menuPath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	bounds, err := diet.ParseBounds(*boundsFlag)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	problem := diet.Problem{Foods: foods, Cost: *cost, Bounds: bounds}
+	fmt.Println("bounds =", bounds)
+
+	d, err := problem.Solve()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Println("Cheapest diet in any servings:", d)
+	if *whole {
+		d, err := problem.SolveWhole(*maxNodes)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Println("Cheapest diet in whole servings:", d)
+	}
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"math/big"
+
+	"./memo"
 )
 
 func fib(n int) int {
@@ -12,26 +14,23 @@ func fib(n int) int {
 	return fib(n-1) + fib(n-2)
 }
 
-func fastFib(n int, memo map[int]big.Int) big.Int {
+func fastFib(n int, memo *memo.Memo[int, *big.Int]) *big.Int {
 	if n == 0 || n == 1 {
-		return *big.NewInt(1)
+		return big.NewInt(1)
 	}
-	val, ok := memo[n]
-	if !ok {
+	return memo.Do(n, func() *big.Int {
 		n1 := fastFib(n-1, memo)
 		n2 := fastFib(n-2, memo)
-		memo[n] = *(new(big.Int).Add(&n1, &n2))
-		return memo[n]
-	}
-	return val
+		return new(big.Int).Add(n1, n2)
+	})
 }
 
 func main() {
+	cache := memo.New[int, *big.Int](0)
 	for i := 1; i <= 120; i++ {
 		//fmt.Println("fib(", i, ") =", fib(i))
-		memo := make(map[int]big.Int)
-		var f big.Int
-		f = fastFib(i, memo)
+		f := fastFib(i, cache)
 		fmt.Printf("fib(%d) = %s\n", i, f.Text(10))
 	}
+	fmt.Println("memo:", cache.Stats())
 }
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"../fibonacci"
+)
+
+// maxPisano is the largest modulus whose Pisano period is printed.
+const maxPisano = 10000000
+
+// parseTerms reads term numbers written as "10,20,100:110".
+func parseTerms(s string) ([]uint64, error) {
+	var terms []uint64
+	for _, part := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), ":")
+		lo, err := strconv.ParseUint(from, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("terms %q: %v", part, err)
+		}
+		hi := lo
+		if isRange {
+			if hi, err = strconv.ParseUint(to, 10, 64); err != nil {
+				return nil, fmt.Errorf("terms %q: %v", part, err)
+			}
+		}
+		for n := lo; n <= hi && n >= lo; n++ {
+			terms = append(terms, n)
+		}
+	}
+	return terms, nil
+}
+
+func parseInts(s string) ([]int64, error) {
+	var ints []int64
+	for _, part := range strings.Split(s, ",") {
+		v, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, v)
+	}
+	return ints, nil
+}
+
+func main() {
+	termsFlag := flag.String("n", "0:20", "terms to print, e.g. 10,20,100:110")
+	method := flag.String("method", "doubling", "Fibonacci method: doubling or matrix")
+	mod := flag.Uint64("mod", 0, "print terms mod m and the Pisano period of m")
+	coeffsFlag := flag.String("coeffs", "", "coefficients of a recurrence a(n) = c1 a(n-1) + ... + ck a(n-k)")
+	initFlag := flag.String("init", "", "initial terms a(0), ..., a(k-1) of the recurrence")
+	flag.Parse()
+
+	terms, err := parseTerms(*termsFlag)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if *coeffsFlag != "" {
+		coeffs, err := parseInts(*coeffsFlag)
+		if err != nil {
+			log.Fatalln("coeffs:", err)
+		}
+		init, err := parseInts(*initFlag)
+		if err != nil {
+			log.Fatalln("init:", err)
+		}
+		r, err := fibonacci.NewRecurrence(coeffs, init)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for _, n := range terms {
+			if *mod > 0 {
+				m := new(big.Int).SetUint64(*mod)
+				fmt.Printf("a(%d) mod %d = %s\n", n, *mod, r.TermMod(n, m))
+			} else {
+				fmt.Printf("a(%d) = %s\n", n, r.Term(n))
+			}
+		}
+		return
+	}
+
+	if *mod > 0 {
+		// Finding the period takes up to 6m steps.
+		if *mod <= maxPisano {
+			fmt.Printf("Pisano period of %d = %d\n", *mod, fibonacci.PisanoPeriod(*mod))
+		}
+		for _, n := range terms {
+			fmt.Printf("F(%d) mod %d = %d\n", n, *mod, fibonacci.Mod(n, *mod))
+		}
+		return
+	}
+
+	fib := fibonacci.FastDoubling
+	switch *method {
+	case "doubling":
+	case "matrix":
+		fib = fibonacci.MatrixPower
+	default:
+		log.Fatalf("unknown method %q, want doubling or matrix", *method)
+	}
+	for _, n := range terms {
+		fmt.Printf("F(%d) = %s\n", n, fib(n))
+	}
+}
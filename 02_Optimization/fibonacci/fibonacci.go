@@ -0,0 +1,92 @@
+// Package fibonacci computes Fibonacci numbers and other constant-coefficient
+// linear recurrences in O(log n) arithmetic operations. Terms are numbered
+// from F(0) = 0, F(1) = 1; the fib of the lectures is F(n+1).
+package fibonacci
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// FastDoubling returns F(n) using F(2k) = F(k)(2F(k+1) - F(k)) and
+// F(2k+1) = F(k)^2 + F(k+1)^2, walking the bits of n without recursion.
+func FastDoubling(n uint64) *big.Int {
+	a, b := big.NewInt(0), big.NewInt(1) // F(k), F(k+1)
+	t := new(big.Int)
+	for i := bits.Len64(n) - 1; i >= 0; i-- {
+		// c = F(2k), d = F(2k+1)
+		t.Lsh(b, 1).Sub(t, a)
+		c := new(big.Int).Mul(a, t)
+		d := new(big.Int).Mul(a, a)
+		d.Add(d, t.Mul(b, b))
+		if n>>uint(i)&1 == 0 {
+			a, b = c, d
+		} else {
+			a, b = d, c.Add(c, d)
+		}
+	}
+	return a
+}
+
+// MatrixPower returns F(n) as the corner of [[1, 1], [1, 0]]^n.
+func MatrixPower(n uint64) *big.Int {
+	m := matPow(matrix{{big.NewInt(1), big.NewInt(1)}, {big.NewInt(1), big.NewInt(0)}}, n, nil)
+	return m[0][1]
+}
+
+// Mod returns F(n) mod m. It panics if m is 0.
+func Mod(n, m uint64) uint64 {
+	if m == 0 {
+		panic("fibonacci: modulus 0")
+	}
+	a, b := uint64(0), 1%m
+	for i := bits.Len64(n) - 1; i >= 0; i-- {
+		t := subMod(addMod(b, b, m), a, m)
+		c := mulMod(a, t, m)
+		d := addMod(mulMod(a, a, m), mulMod(b, b, m), m)
+		if n>>uint(i)&1 == 0 {
+			a, b = c, d
+		} else {
+			a, b = d, addMod(c, d, m)
+		}
+	}
+	return a
+}
+
+// PisanoPeriod returns the period of F(n) mod m. It steps through the
+// sequence, and the period can be as long as 6m. It panics if m is 0.
+func PisanoPeriod(m uint64) uint64 {
+	if m == 0 {
+		panic("fibonacci: modulus 0")
+	}
+	if m == 1 {
+		return 1
+	}
+	a, b := uint64(0), uint64(1)
+	for i := uint64(1); ; i++ {
+		a, b = b, addMod(a, b, m)
+		if a == 0 && b == 1 {
+			return i
+		}
+	}
+}
+
+func addMod(a, b, m uint64) uint64 {
+	s, carry := bits.Add64(a, b, 0)
+	if carry != 0 || s >= m {
+		s -= m
+	}
+	return s
+}
+
+func subMod(a, b, m uint64) uint64 {
+	if a >= b {
+		return a - b
+	}
+	return m - (b - a)
+}
+
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
@@ -0,0 +1,119 @@
+package fibonacci
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// terms are F(0) to F(n) by adding.
+func terms(n int) []*big.Int {
+	f := []*big.Int{big.NewInt(0), big.NewInt(1)}
+	for len(f) <= n {
+		f = append(f, new(big.Int).Add(f[len(f)-1], f[len(f)-2]))
+	}
+	return f[:n+1]
+}
+
+func TestFastDoublingAndMatrixPower(t *testing.T) {
+	for n, want := range terms(500) {
+		if got := FastDoubling(uint64(n)); got.Cmp(want) != 0 {
+			t.Fatalf("FastDoubling(%d) = %v, want %v", n, got, want)
+		}
+		if got := MatrixPower(uint64(n)); got.Cmp(want) != 0 {
+			t.Fatalf("MatrixPower(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestMod(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	f := terms(2000)
+	for _, m := range []uint64{1, 2, 10, 1000000007, 1 << 63, math.MaxUint64} {
+		for k := 0; k < 200; k++ {
+			n := r.Intn(len(f))
+			want := new(big.Int).Mod(f[n], new(big.Int).SetUint64(m))
+			if got := Mod(uint64(n), m); got != want.Uint64() {
+				t.Fatalf("Mod(%d, %d) = %d, want %v", n, m, got, want)
+			}
+		}
+	}
+}
+
+func TestPisanoPeriod(t *testing.T) {
+	known := map[uint64]uint64{1: 1, 2: 3, 3: 8, 4: 6, 5: 20, 10: 60, 100: 300, 1000: 1500}
+	for m, want := range known {
+		if got := PisanoPeriod(m); got != want {
+			t.Errorf("PisanoPeriod(%d) = %d, want %d", m, got, want)
+		}
+	}
+	for m := uint64(2); m < 200; m++ {
+		p := PisanoPeriod(m)
+		if Mod(p, m) != 0 || Mod(p+1, m) != 1 {
+			t.Errorf("F(n) mod %d does not repeat after %d", m, p)
+		}
+	}
+}
+
+func TestModulusZeroPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"Mod":          func() { Mod(5, 0) },
+		"PisanoPeriod": func() { PisanoPeriod(0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s with modulus 0 did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestRecurrence(t *testing.T) {
+	fib, err := NewRecurrence([]int64{1, 1}, []int64{0, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := terms(300)
+	for _, n := range []int{0, 1, 2, 10, 93, 94, 300} {
+		if got := fib.Term(uint64(n)); got.Cmp(f[n]) != 0 {
+			t.Errorf("fibonacci Term(%d) = %v, want %v", n, got, f[n])
+		}
+	}
+
+	// Tribonacci: 0, 0, 1, 1, 2, 4, 7, 13, 24, 44, ...
+	trib, err := NewRecurrence([]int64{1, 1, 1}, []int64{0, 0, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int64{0, 0, 1, 1, 2, 4, 7, 13, 24, 44, 81, 149, 274}
+	for n, w := range want {
+		if got := trib.Term(uint64(n)); got.Int64() != w {
+			t.Errorf("tribonacci Term(%d) = %v, want %d", n, got, w)
+		}
+	}
+
+	// a(n) = 2a(n-1) - a(n-2) from 5, 3 counts down by 2, below zero.
+	down, err := NewRecurrence([]int64{2, -1}, []int64{5, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := big.NewInt(7)
+	for n := 0; n < 20; n++ {
+		w := big.NewInt(int64(5 - 2*n))
+		if got := down.Term(uint64(n)); got.Cmp(w) != 0 {
+			t.Errorf("countdown Term(%d) = %v, want %v", n, got, w)
+		}
+		if got := down.TermMod(uint64(n), m); got.Cmp(new(big.Int).Mod(w, m)) != 0 {
+			t.Errorf("countdown TermMod(%d, 7) = %v, want %v", n, got, new(big.Int).Mod(w, m))
+		}
+	}
+
+	if _, err := NewRecurrence([]int64{1, 1}, []int64{1}); !errors.Is(err, ErrRecurrence) {
+		t.Errorf("got %v, want ErrRecurrence", err)
+	}
+}
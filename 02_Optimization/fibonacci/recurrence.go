@@ -0,0 +1,127 @@
+package fibonacci
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// matrix is a square matrix of big integers.
+type matrix [][]*big.Int
+
+func zero(k int) matrix {
+	z := make(matrix, k)
+	for i := range z {
+		z[i] = make([]*big.Int, k)
+		for j := range z[i] {
+			z[i][j] = new(big.Int)
+		}
+	}
+	return z
+}
+
+func identity(k int) matrix {
+	id := zero(k)
+	for i := range id {
+		id[i][i].SetInt64(1)
+	}
+	return id
+}
+
+// mul returns a*b, reduced mod m unless m is nil.
+func (a matrix) mul(b matrix, m *big.Int) matrix {
+	k := len(a)
+	c := zero(k)
+	t := new(big.Int)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			for l := 0; l < k; l++ {
+				c[i][j].Add(c[i][j], t.Mul(a[i][l], b[l][j]))
+			}
+			if m != nil {
+				c[i][j].Mod(c[i][j], m)
+			}
+		}
+	}
+	return c
+}
+
+// matPow returns a^n by repeated squaring, reduced mod m unless m is nil.
+func matPow(a matrix, n uint64, m *big.Int) matrix {
+	result := identity(len(a))
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = result.mul(a, m)
+		}
+		a = a.mul(a, m)
+	}
+	return result
+}
+
+// Recurrence is a(n) = Coeffs[0] a(n-1) + ... + Coeffs[k-1] a(n-k) for
+// n >= k, with a(n) = Init[n] for n < k.
+type Recurrence struct {
+	Coeffs []*big.Int
+	Init   []*big.Int
+}
+
+var ErrRecurrence = errors.New("fibonacci: need as many initial terms as coefficients")
+
+// NewRecurrence builds a Recurrence from small coefficients and terms.
+// Fibonacci is NewRecurrence([]int64{1, 1}, []int64{0, 1}).
+func NewRecurrence(coeffs, init []int64) (*Recurrence, error) {
+	if len(coeffs) == 0 || len(coeffs) != len(init) {
+		return nil, fmt.Errorf("%w, got %d and %d", ErrRecurrence, len(coeffs), len(init))
+	}
+	r := &Recurrence{}
+	for i := range coeffs {
+		r.Coeffs = append(r.Coeffs, big.NewInt(coeffs[i]))
+		r.Init = append(r.Init, big.NewInt(init[i]))
+	}
+	return r, nil
+}
+
+// companion is the matrix taking (a(n+k-1), ..., a(n)) to
+// (a(n+k), ..., a(n+1)).
+func (r *Recurrence) companion() matrix {
+	k := len(r.Coeffs)
+	c := zero(k)
+	for j, coeff := range r.Coeffs {
+		c[0][j].Set(coeff)
+	}
+	for i := 1; i < k; i++ {
+		c[i][i-1].SetInt64(1)
+	}
+	return c
+}
+
+// Term returns a(n).
+func (r *Recurrence) Term(n uint64) *big.Int {
+	return r.term(n, nil)
+}
+
+// TermMod returns a(n) mod m, in [0, m).
+func (r *Recurrence) TermMod(n uint64, m *big.Int) *big.Int {
+	return r.term(n, m)
+}
+
+func (r *Recurrence) term(n uint64, m *big.Int) *big.Int {
+	k := uint64(len(r.Coeffs))
+	if n < k {
+		a := new(big.Int).Set(r.Init[n])
+		if m != nil {
+			a.Mod(a, m)
+		}
+		return a
+	}
+	p := matPow(r.companion(), n-k+1, m)
+	a := new(big.Int)
+	t := new(big.Int)
+	for j := uint64(0); j < k; j++ {
+		a.Add(a, t.Mul(p[0][j], r.Init[k-1-j]))
+	}
+	if m != nil {
+		a.Mod(a, m)
+	}
+	return a
+}
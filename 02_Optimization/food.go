@@ -1,206 +1,317 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"log"
+	"math"
 	"math/rand"
-	"sort"
+	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"./menu"
 )
 
-// Food Module
-type Food struct {
-	name     string
-	value    int
-	calories int
+func testGreedy(items []menu.Food, constraint float64, compFunc menu.CompFunction) {
+	taken, val := menu.Greedy(items, menu.CalorieBudget(constraint), compFunc)
+	fmt.Println("Total value of items taken =", val)
+	for _, item := range taken {
+		fmt.Println("   ", item)
+	}
 }
 
-func (f Food) getValue() int    { return f.value }
-func (f Food) getCost() int     { return f.calories }
-func (f Food) density() float64 { return float64(f.value) / float64(f.calories) }
-func (f Food) String() string {
-	return fmt.Sprintf("%s: <%d, %d>", f.name, f.value, f.calories)
-}
+func testGreedys(foods []menu.Food, maxUnits float64) {
+	fmt.Println("Use greedy by value to allocate", maxUnits, "calories")
+	testGreedy(foods, maxUnits, menu.ByValue)
+	fmt.Println()
 
-// names, vallues, calories list the same length.
-// name a list of strings
-// values and calories lists of numbers
-// return list of Foods
-func buildMenu(names []string, values []int, calories []int) []Food {
-	var menu []Food
-	for i, v := range values {
-		var f Food
-		f.name = names[i]
-		f.value = v
-		f.calories = calories[i]
-		menu = append(menu, f)
-	}
+	fmt.Println("Use greedy by cost to allocate", maxUnits, "calories")
+	testGreedy(foods, maxUnits, menu.ByCost)
+	fmt.Println()
 
-	return menu
+	fmt.Println("Use greedy by density to allocate", maxUnits, "calories")
+	testGreedy(foods, maxUnits, menu.ByDensity)
+	fmt.Println()
 }
 
-type customSort struct {
-	t    []Food
-	less func(x, y Food) bool
+func testSolver(name string, foods []menu.Food, budget menu.Budget, printItems bool) {
+	solver, err := menu.NewSolver(name)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	sel, err := solver.Solve(foods, budget)
+	if err != nil {
+		fmt.Println("   ", err)
+		return
+	}
+	printSelection(sel, printItems)
 }
 
-func (x customSort) Len() int           { return len(x.t) }
-func (x customSort) Less(i, j int) bool { return x.less(x.t[i], x.t[j]) }
-func (x customSort) Swap(i, j int)      { x.t[i], x.t[j] = x.t[j], x.t[i] }
-
-type CompFunction func(x, y Food) bool
+func printSelection(sel menu.Selection, printItems bool) {
+	fmt.Println("Total value of items taken =", sel.Value)
+	if printItems {
+		for i, item := range sel.Items {
+			if sel.Quantities == nil {
+				fmt.Println("   ", item)
+			} else {
+				fmt.Printf("    %g x %s\n", sel.Quantity(i), item)
+			}
+		}
+	}
+}
 
-func greedy(items []Food, maxCost int, compFunc CompFunction) ([]Food, int) {
-	itemsCopy := items[:]
+func testMode(foods []menu.Food, budget menu.Budget, mode menu.Mode) {
+	fmt.Printf("Use %s portions to allocate %v\n", mode, budget)
+	sel, err := menu.NewModeSolver(mode).Solve(foods, budget)
+	if err != nil {
+		log.Fatalln(mode, err)
+	}
+	printSelection(sel, true)
+}
 
-	sort.Sort(customSort{itemsCopy, compFunc})
-	//fmt.Println(itemsCopy)
+// testRules solves with the rules of the file, exactly and with each greedy
+// strategy, and checks every selection against them.
+func testRules(foods []menu.Food, budget menu.Budget, path string) {
+	rules, err := menu.LoadConstraints(path)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Printf("Rules:\n%s", rules)
+	testRuleSolver("search", menu.ConstrainedSearch{Rules: rules}, foods, budget, rules)
+	for _, g := range menu.GreedyStrategies {
+		solver := menu.ConstrainedGreedySolver{Order: g.Order, Rules: rules}
+		testRuleSolver("greedy by "+g.Name, solver, foods, budget, rules)
+	}
+}
 
-	var result []Food
-	totalValue := 0
-	totalCost := 0
+func testRuleSolver(name string, solver menu.Solver, foods []menu.Food, budget menu.Budget, rules *menu.Constraints) {
+	fmt.Printf("Use %s with rules to allocate %v\n", name, budget)
+	sel, err := solver.Solve(foods, budget)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	printSelection(sel, true)
+	if err := rules.Check(foods, sel.Items); err != nil {
+		log.Fatalln(err)
+	}
+}
 
-	for _, item := range itemsCopy {
-		if (totalCost + item.getCost()) <= maxCost {
-			result = append(result, item)
-			totalCost += item.getCost()
-			totalValue += item.getValue()
+// testSensitivity prints how firmly each food is in or out of the optimum
+// and the value of extra calories, then raises the value of the first food
+// left out just enough to bring it in.
+func testSensitivity(foods []menu.Food, budget menu.Budget, extra int) {
+	a, err := menu.Analyze(foods, budget, extra)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if err := a.Write(os.Stdout); err != nil {
+		log.Fatalln(err)
+	}
+	for i, it := range a.Items {
+		if it.Taken || math.IsInf(it.Flip, 1) {
+			continue
+		}
+		var f menu.Food
+		f.Init(it.Food.Name(), it.Food.Value()+it.Flip+1, it.Food.Cost())
+		for _, k := range it.Food.AttrKeys() {
+			v, _ := it.Food.Attr(k)
+			f.SetAttr(k, v)
 		}
+		sel, err := a.WhatIf(i, f)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("What if %s were worth %g:\n", f.Name(), f.Value())
+		printSelection(sel, true)
+		break
 	}
-	//fmt.Println("total cost=", totalCost)
-
-	return result, totalValue
 }
 
-func testGreedy(items []Food, constraint int, compFunc CompFunction) {
-	taken, val := greedy(items, constraint, compFunc)
-	fmt.Println("Total value of items taken =", val)
-	for _, item := range taken {
-		fmt.Println("   ", item)
+// testApproximation solves with the FPTAS and with costs rounded to digits
+// decimal places, when asked for, and prints how far each may be from the
+// optimum.
+func testApproximation(foods []menu.Food, budget menu.Budget, epsilon float64, digits int) {
+	if epsilon > 0 {
+		a, err := menu.FPTAS{Epsilon: epsilon}.Approximate(foods, budget)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("Use the FPTAS with epsilon %g to allocate %v\n", epsilon, budget)
+		printSelection(a.Selection, true)
+		fmt.Printf("No selection is worth more than %.4g\n", a.Bound)
+	}
+	if digits >= 0 {
+		r, err := menu.ScaledDP{Digits: digits}.SolveRounded(foods, budget)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("Use costs rounded to %d decimal places to allocate %v\n", digits, budget)
+		printSelection(r.Selection, true)
+		fmt.Printf("Rounding charged %v more and may have lost up to %.4g\n", r.Slack, r.Gap())
 	}
 }
 
-func testGreedys(foods []Food, maxUnits int) {
-	fmt.Println("Use greedy by value to allocate", maxUnits, "calories")
-	testGreedy(foods, maxUnits, func(x, y Food) bool {
-		return x.value > y.value
-	})
-	fmt.Println()
+func testMaxVal(foods []menu.Food, maxUnits float64, printItems bool) {
+	fmt.Println("Use search tree to allocate", maxUnits, "calories")
+	testSolver("maxval", foods, menu.CalorieBudget(maxUnits), printItems)
+}
 
-	fmt.Println("Use greedy by cost to allocate", maxUnits, "calories")
-	testGreedy(foods, maxUnits, func(x, y Food) bool {
-		return x.calories < y.calories
-	})
-	fmt.Println()
+func testFastMaxVal(foods []menu.Food, maxUnits float64, printItems bool) {
+	fmt.Println("Use search tree to allocate", maxUnits, "calories")
+	testSolver("fastmaxval", foods, menu.CalorieBudget(maxUnits), printItems)
+}
 
-	fmt.Println("Use greedy by density to allocate", maxUnits, "calories")
-	testGreedy(foods, maxUnits, func(x, y Food) bool {
-		return x.density() > y.density()
-	})
-	fmt.Println()
+func testSolvers(foods []menu.Food, budget menu.Budget) {
+	for _, name := range menu.SolverNames() {
+		fmt.Printf("Use %s to allocate %v\n", name, budget)
+		testSolver(name, foods, budget, false)
+	}
 }
 
-func maxVal(toConsider []Food, avail int) (int, []Food) {
-	var taken []Food
-	var val int
-	if len(toConsider) == 0 || avail == 0 {
-		val, taken = 0, nil
-	} else if toConsider[0].getCost() > avail {
-		// Explore right branch only
-		val, taken = maxVal(toConsider[1:], avail)
-	} else {
-		nextItem := toConsider[0]
-		// Explore left branch
-		withVal, withToTake := maxVal(toConsider[1:], avail-nextItem.getCost())
-		withVal += nextItem.getValue()
-		// Explore right branch
-		withoutVal, withoutToTake := maxVal(toConsider[1:], avail)
-		// Choose better branch
-		if withVal > withoutVal {
-			val, taken = withVal, append(withToTake, nextItem)
+// writeTrace writes the trace as name.dot and name.json.
+func writeTrace(tr *menu.Tracer, name string) {
+	for _, ext := range []string{".dot", ".json"} {
+		f, err := os.Create(name + ext)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if ext == ".dot" {
+			err = tr.WriteDOT(f)
 		} else {
-			val, taken = withoutVal, withoutToTake
+			err = tr.WriteJSON(f)
+		}
+		if err != nil {
+			log.Fatalln(err)
 		}
+		f.Close()
 	}
-	return val, taken
+	fmt.Println("Wrote", len(tr.Nodes), "search nodes to", name+".dot and", name+".json")
 }
 
-func testMaxVal(foods []Food, maxUnits int, printItems bool) {
-	fmt.Println("Use search tree to allocate", maxUnits, "calories")
-	val, taken := maxVal(foods, maxUnits)
-	fmt.Println("Total value of items taken =", val)
-	if printItems {
-		for _, item := range taken {
-			fmt.Println("   ", item)
+func traceSearches(foods []menu.Food, maxUnits float64, prefix string) {
+	budget := menu.CalorieBudget(maxUnits)
+	var tr menu.Tracer
+	if _, err := (menu.SearchTree{Trace: &tr}).Solve(foods, budget); err != nil {
+		log.Fatalln(err)
+	}
+	writeTrace(&tr, prefix+"-maxval")
+	tr = menu.Tracer{}
+	if _, err := (menu.MemoSearch{Trace: &tr}).Solve(foods, budget); err != nil {
+		log.Fatalln(err)
+	}
+	writeTrace(&tr, prefix+"-fastmaxval")
+}
+
+// testInstances times the exact solvers on every instance of the file and
+// checks them against its known optimum.
+func testInstances(path string) {
+	instances, err := menu.LoadInstances(path)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	for _, inst := range instances {
+		fmt.Printf("%s: %d items, %v, optimum %.0f\n", inst.Name, len(inst.Menu), inst.Budget, inst.Optimum)
+		for _, name := range []string{"dp", "bnb", "parallel-bnb"} {
+			solver, err := menu.NewSolver(name)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			start := time.Now()
+			sel, err := solver.Solve(inst.Menu, inst.Budget)
+			if err != nil {
+				fmt.Printf("    %s: %v\n", name, err)
+				continue
+			}
+			fmt.Printf("    %s: value %.0f, %.2fs elapsed", name, sel.Value, time.Since(start).Seconds())
+			if inst.Optimum != 0 && sel.Value != inst.Optimum {
+				fmt.Print(" (differs from the optimum)")
+			}
+			fmt.Println()
 		}
 	}
 }
 
-func buildLargeMenu(numItems int, maxVal int, maxCost int) []Food {
-	var items []Food
+// testAnytime runs solver for at most limit and reports how far its
+// selection may be from the optimum if it had to stop.
+func testAnytime(name string, solver menu.AnytimeSolver, items []menu.Food, budget menu.Budget, limit time.Duration) menu.Anytime {
+	ctx, cancel := context.WithTimeout(context.Background(), limit)
+	defer cancel()
+	start := time.Now()
+	res, err := solver.SolveContext(ctx, items, budget)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Println("Total value of items taken =", res.Value)
+	if !res.Complete {
+		fmt.Printf("%s stopped: bound %.0f, gap %.1f%%\n", name, res.Bound, 100*res.RelGap())
+	}
+	fmt.Printf("%s %.2fs elapsed\n", name, time.Since(start).Seconds())
+	return res
+}
+
+func buildLargeMenu(r *rand.Rand, numItems int, maxVal int, maxCost int) []menu.Food {
+	var items []menu.Food
 
 	for i := 0; i < numItems; i++ {
+		var f menu.Food
 		name := strconv.Itoa(i)
-		val := rand.Intn(maxVal) + 1
-		calories := rand.Intn(maxCost) + 1
-		items = append(items, Food{name, val, calories})
+		val := r.Intn(maxVal) + 1
+		calories := r.Intn(maxCost) + 1
+		f.Init(name, float64(val), float64(calories))
+		items = append(items, f)
 	}
 
 	return items
 }
 
-type Result struct {
-	val   int
-	taken []Food
-}
+func main() {
+	menuPath := flag.String("menu", "", "read the menu from a .csv or .json file")
+	budgetFlag := flag.String("budget", "calories=750", "budget of every solver, e.g. calories=750,price=20, or a .budget file saved with a menu")
+	modeFlag := flag.String("mode", "", "also solve with portions: fractional, bounded or unbounded")
+	tracePrefix := flag.String("trace", "", "write the maxVal and fastMaxVal search trees to files with this prefix")
+	rulesPath := flag.String("rules", "", "also solve with the requires, excludes and atmost rules of a file")
+	limit := flag.Duration("limit", 2*time.Second, "time given to maxVal and fastMaxVal on each large menu")
+	sensitivity := flag.Int("sensitivity", -1, "analyze the sensitivity of the optimum, with the value of this many extra calories")
+	epsilon := flag.Float64("epsilon", 0, "also solve with the FPTAS within this fraction of the optimum")
+	digits := flag.Int("digits", -1, "also solve with costs rounded up to this many decimal places")
+	top := flag.Int("top", 0, "list this many best selections, and list no more than this many for -within")
+	within := flag.Float64("within", -1, "list the best selections within this many percent of the optimum, as many as -top or else 100")
+	seed := flag.Int64("seed", 1, "seed of the large menus")
+	instancesPath := flag.String("instances", "", "solve the standard instances of a Pisinger .csv or OR-Library .txt file and exit")
+	familyFlag := flag.String("family", "", "draw the large menus from a family: uncorrelated, weakly-correlated, strongly-correlated, inverse-strongly-correlated or subset-sum")
+	flag.Parse()
 
-func fastMaxVal(toConsider []Food, avail int, memo map[string]Result) (int, []Food) {
-	var taken []Food
-	var val int
-	key := fmt.Sprintf("len=%d,avail=%d", len(toConsider), avail)
-	r, ok := memo[key]
-	if ok {
-		val, taken = r.val, r.taken
-	} else if len(toConsider) == 0 || avail == 0 {
-		val, taken = 0, nil
-	} else if toConsider[0].getCost() > avail {
-		// Explore right branch only
-		val, taken = fastMaxVal(toConsider[1:], avail, memo)
-	} else {
-		nextItem := toConsider[0]
-		// Explore left branch
-		withVal, withToTake := fastMaxVal(toConsider[1:], avail-nextItem.getCost(), memo)
-		withVal += nextItem.getValue()
-		// Explore right branch
-		withoutVal, withoutToTake := fastMaxVal(toConsider[1:], avail, memo)
-		// Choose better branch
-		if withVal > withoutVal {
-			val, taken = withVal, append(withToTake, nextItem)
-		} else {
-			val, taken = withoutVal, withoutToTake
-		}
+	if *instancesPath != "" {
+		testInstances(*instancesPath)
+		return
 	}
-	memo[key] = Result{val, taken}
-	return val, taken
-}
 
-func testFastMaxVal(foods []Food, maxUnits int, printItems bool) {
-	fmt.Println("Use search tree to allocate", maxUnits, "calories")
-	memo := make(map[string]Result)
-	val, taken := fastMaxVal(foods, maxUnits, memo)
-	fmt.Println("Total value of items taken =", val)
-	if printItems {
-		for _, item := range taken {
-			fmt.Println("   ", item)
-		}
+	var budget menu.Budget
+	var err error
+	if strings.HasSuffix(*budgetFlag, ".budget") {
+		budget, err = menu.LoadBudget(*budgetFlag)
+	} else {
+		budget, err = menu.ParseBudget(*budgetFlag)
+	}
+	if err != nil {
+		log.Fatalln(err)
 	}
-}
 
-func main() {
-	names := [...]string{"wine", "beer", "pizza", "burger", "fries", "cola", "apple", "donut", "cake"}
-	values := [...]int{89, 90, 95, 100, 90, 79, 50, 10}
-	calories := [...]int{123, 154, 258, 354, 365, 150, 95, 195}
-	foods := buildMenu(names[:], values[:], calories[:])
+	var foods []menu.Food
+	if *menuPath != "" {
+		foods, err = menu.Load(*menuPath)
+	} else {
+		names := [...]string{"wine", "beer", "pizza", "burger", "fries", "cola", "apple", "donut"}
+		values := [...]float64{89, 90, 95, 100, 90, 79, 50, 10}
+		calories := [...]float64{123, 154, 258, 354, 365, 150, 95, 195}
+		foods, err = menu.BuildMenu(names[:], values[:], calories[:])
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
 	fmt.Println("menu =", foods)
 	fmt.Println()
 
@@ -210,17 +321,98 @@ func main() {
 
 	testMaxVal(foods, 750, true)
 	testFastMaxVal(foods, 750, true)
+	if *tracePrefix != "" {
+		traceSearches(foods, 750, *tracePrefix)
+	}
+
+	fmt.Println()
+	testSolvers(foods, budget)
+
+	if *modeFlag != "" {
+		mode, err := menu.ParseMode(*modeFlag)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Println()
+		testMode(foods, budget, mode)
+	}
+
+	if *sensitivity >= 0 {
+		fmt.Println()
+		testSensitivity(foods, budget, *sensitivity)
+	}
+
+	if *epsilon > 0 || *digits >= 0 {
+		fmt.Println()
+		testApproximation(foods, budget, *epsilon, *digits)
+	}
+
+	if *top > 0 {
+		fmt.Println()
+		alts, err := menu.TopK(foods, budget, *top)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("The %d best selections:\n", *top)
+		alts.Write(os.Stdout)
+	}
+	if *within >= 0 {
+		fmt.Println()
+		limit := *top
+		if limit <= 0 {
+			limit = 100
+		}
+		alts, err := menu.WithinPercent(foods, budget, *within, limit)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Printf("The selections within %g%% of the optimum:\n", *within)
+		alts.Write(os.Stdout)
+	}
+
+	if *rulesPath != "" {
+		fmt.Println()
+		testRules(foods, budget, *rulesPath)
+	}
 
 	fmt.Println()
-	rand.Seed(time.Now().UTC().UnixNano())
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
 	for numItems := 5; numItems <= 50; numItems += 5 {
 		fmt.Println("Try a menu with", numItems, "items")
-		items := buildLargeMenu(numItems, 90, 250)
+		var items []menu.Food
+		budget := menu.CalorieBudget(750)
+		if *familyFlag != "" {
+			family, err := menu.ParseFamily(*familyFlag)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			items, err = menu.Generate(family, numItems, 250, *seed+int64(numItems))
+			if err != nil {
+				log.Fatalln(err)
+			}
+			budget = menu.HalfCapacity(items)
+		} else {
+			items = buildLargeMenu(r, numItems, 90, 250)
+		}
+		exact := testAnytime("maxVal", menu.SearchTree{}, items, budget, *limit)
+		testAnytime("fastMaxVal", menu.MemoSearch{}, items, budget, *limit)
 		start := time.Now()
-		testMaxVal(items, 750, false)
-		fmt.Printf("maxVal %.2fs elapsed\n", time.Since(start).Seconds())
-		start = time.Now()
-		testFastMaxVal(items, 750, false)
-		fmt.Printf("fastMaxVal %.2fs elapsed\n", time.Since(start).Seconds())
+		sel, err := menu.ParallelBranchBound{}.Solve(items, budget)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		fmt.Println("Total value of items taken =", sel.Value)
+		fmt.Printf("parallel branch and bound %.2fs elapsed\n", time.Since(start).Seconds())
+		if exact.Complete && fmt.Sprint(sel.Items) != fmt.Sprint(exact.Items) {
+			log.Fatalln("parallel branch and bound differs from maxVal:", sel, exact.Selection)
+		}
+		if *epsilon > 0 {
+			a, err := menu.FPTAS{Epsilon: *epsilon}.Approximate(items, budget)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Printf("FPTAS value = %g, %.1f%% of the optimum\n", a.Value, 100*a.Value/sel.Value)
+		}
 	}
 }
@@ -0,0 +1,84 @@
+package heuristic
+
+import (
+	"math"
+	"math/rand"
+
+	"../menu"
+)
+
+// Annealing is simulated annealing. Each iteration flips a random item,
+// giving back random items until the selection fits again, and keeps the
+// change if it gains value, or loses loss with probability exp(-loss/T).
+// The temperature T cools geometrically from Start to a thousandth of it.
+type Annealing struct {
+	Seed       int64
+	Iterations int     // defaults to 100000
+	Start      float64 // defaults to the mean value of an item
+	Trace      *Trace
+}
+
+func (a Annealing) Solve(items []menu.Food, budget menu.Budget) (menu.Selection, error) {
+	in, err := newInstance(items, budget)
+	if err != nil {
+		return menu.Selection{}, err
+	}
+	n := len(items)
+	if n == 0 {
+		return menu.NewSelection(nil, budget), nil
+	}
+	r := rand.New(rand.NewSource(a.Seed))
+	iterations := a.Iterations
+	if iterations <= 0 {
+		iterations = 100000
+	}
+	temp := a.Start
+	if temp <= 0 {
+		for _, v := range in.values {
+			temp += v
+		}
+		temp = math.Max(temp/float64(n), 1e-9)
+	}
+	cooling := math.Pow(1e-3, 1/float64(iterations))
+
+	// Items that do not fit even alone are never flipped.
+	alone := make([]bool, n)
+	empty := in.empty()
+	for i := range alone {
+		alone[i] = in.fits(empty, i)
+	}
+
+	cur := in.empty()
+	in.repair(cur)
+	best := in.empty()
+	best.copyFrom(cur)
+	var flipped []int
+	for it := 0; it < iterations; it++ {
+		if i := r.Intn(n); alone[i] {
+			before := cur.value
+			flipped = append(flipped[:0], i)
+			in.flip(cur, i)
+			for !in.feasible(cur) {
+				// Give back a random item other than the one just taken.
+				j := r.Intn(n)
+				for !cur.taken[j] || j == i {
+					j = (j + 1) % n
+				}
+				in.flip(cur, j)
+				flipped = append(flipped, j)
+			}
+			if loss := before - cur.value; loss > 0 && r.Float64() >= math.Exp(-loss/temp) {
+				for k := len(flipped) - 1; k >= 0; k-- {
+					in.flip(cur, flipped[k])
+				}
+			}
+			if cur.value > best.value {
+				best.copyFrom(cur)
+			}
+		}
+		temp *= cooling
+		a.Trace.record(it, cur.value, best.value)
+	}
+	in.repair(best)
+	return in.selection(best, budget), nil
+}
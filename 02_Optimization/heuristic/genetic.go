@@ -0,0 +1,110 @@
+package heuristic
+
+import (
+	"math/rand"
+
+	"../menu"
+)
+
+// Genetic evolves a population of selections. Each child takes every item
+// from one of two parents, each the better of two picked at random, flips
+// items with chance Mutation and is repaired back into the budget. The
+// best selection always survives to the next generation.
+type Genetic struct {
+	Seed        int64
+	Population  int     // defaults to 50
+	Generations int     // defaults to 200
+	Mutation    float64 // defaults to one item per child
+	Trace       *Trace
+}
+
+func (g Genetic) Solve(items []menu.Food, budget menu.Budget) (menu.Selection, error) {
+	in, err := newInstance(items, budget)
+	if err != nil {
+		return menu.Selection{}, err
+	}
+	n := len(items)
+	r := rand.New(rand.NewSource(g.Seed))
+	size := g.Population
+	if size < 2 {
+		size = 50
+	}
+	generations := g.Generations
+	if generations <= 0 {
+		generations = 200
+	}
+	mutation := g.Mutation
+	if mutation <= 0 && n > 0 {
+		mutation = 1 / float64(n)
+	}
+
+	// Start from random selections, each filled in a random order, and
+	// the greedy one.
+	pop := make([]*solution, size)
+	for k := range pop {
+		s := in.empty()
+		if k == 0 {
+			in.repair(s)
+		} else {
+			for _, i := range r.Perm(n) {
+				if in.fits(s, i) {
+					in.flip(s, i)
+				}
+			}
+		}
+		pop[k] = s
+	}
+	next := make([]*solution, size)
+	for k := range next {
+		next[k] = in.empty()
+	}
+	pick := func() *solution {
+		a, b := pop[r.Intn(size)], pop[r.Intn(size)]
+		if b.value > a.value {
+			return b
+		}
+		return a
+	}
+
+	best := fittest(pop)
+	for gen := 0; gen < generations; gen++ {
+		next[0].copyFrom(best)
+		for k := 1; k < size; k++ {
+			mother, father := pick(), pick()
+			child := next[k]
+			for i := range child.taken {
+				parent := mother
+				if r.Intn(2) == 0 {
+					parent = father
+				}
+				child.taken[i] = parent.taken[i]
+				if r.Float64() < mutation {
+					child.taken[i] = !child.taken[i]
+				}
+			}
+			in.repair(child)
+		}
+		pop, next = next, pop
+		best = fittest(pop)
+		g.Trace.record(gen, mean(pop), best.value)
+	}
+	return in.selection(best, budget), nil
+}
+
+func fittest(pop []*solution) *solution {
+	best := pop[0]
+	for _, s := range pop[1:] {
+		if s.value > best.value {
+			best = s
+		}
+	}
+	return best
+}
+
+func mean(pop []*solution) float64 {
+	total := 0.0
+	for _, s := range pop {
+		total += s.value
+	}
+	return total / float64(len(pop))
+}
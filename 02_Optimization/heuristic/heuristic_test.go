@@ -0,0 +1,81 @@
+package heuristic
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"../menu"
+)
+
+// randomMenu has n foods with values from -5 to 14, some free, and costs
+// in calories and price, within a budget of some of the total of each.
+func randomMenu(r *rand.Rand, n int) ([]menu.Food, menu.Budget) {
+	var items []menu.Food
+	budget := menu.Budget{menu.Calories: 0, "price": 0}
+	for i := 0; i < n; i++ {
+		var f menu.Food
+		f.Init(fmt.Sprint("f", i), float64(r.Intn(20)-5), float64(r.Intn(7)))
+		f.SetAttr("price", float64(r.Intn(5)))
+		items = append(items, f)
+		budget[menu.Calories] += f.Cost()
+		budget["price"] += 4
+	}
+	for d, total := range budget {
+		budget[d] = float64(r.Intn(int(total) + 1))
+	}
+	return items, budget
+}
+
+// TestHeuristicsFitAndBeatGreedy checks that every heuristic returns a
+// selection within the budget, worth at least as much as greedy by density
+// and no more than the optimum.
+func TestHeuristicsFitAndBeatGreedy(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for k := 0; k < 200; k++ {
+		items, budget := randomMenu(r, 2+r.Intn(9))
+		greedy, err := menu.GreedySolver{Order: menu.NormalizedDensity}.Solve(items, budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opt, err := menu.SearchTree{}.Solve(items, budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range []menu.Solver{
+			Annealing{Seed: int64(k), Iterations: 2000},
+			Genetic{Seed: int64(k), Generations: 20},
+			Tabu{Seed: int64(k), Iterations: 100},
+		} {
+			sel, err := s.Solve(items, budget)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for d, c := range sel.Costs {
+				if c > budget[d] {
+					t.Fatalf("%T: %v over the budget %v", s, sel, budget)
+				}
+			}
+			if sel.Value < greedy.Value || sel.Value > opt.Value {
+				t.Fatalf("%v within %v: %T worth %v, greedy %v, optimum %v", items, budget, s, sel.Value, greedy.Value, opt.Value)
+			}
+		}
+	}
+}
+
+func TestRepairSkipsWorthlessFoods(t *testing.T) {
+	items, err := menu.BuildMenu([]string{"a", "b", "c"}, []float64{10, -5, -3}, []float64{5, 1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	budget := menu.CalorieBudget(10)
+	for _, s := range []menu.Solver{Annealing{Seed: 1}, Genetic{Seed: 1}, Tabu{Seed: 1}} {
+		sel, err := s.Solve(items, budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sel.Value != 10 {
+			t.Errorf("%T: value %v, want 10: %v", s, sel.Value, sel)
+		}
+	}
+}
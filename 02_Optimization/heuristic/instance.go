@@ -0,0 +1,166 @@
+// Package heuristic solves large menus approximately with metaheuristics:
+// simulated annealing, a genetic algorithm and tabu search. Each is a
+// menu.Solver, draws from a seeded random source and can record how it
+// converges in a Trace.
+package heuristic
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"../menu"
+)
+
+// instance is a menu and budget laid out for the searches: the cost of item
+// i in dimension d is costs[i][d] and its limit is caps[d].
+type instance struct {
+	items  []menu.Food
+	values []float64
+	costs  [][]float64
+	caps   []float64
+	order  []int // items by decreasing normalized density
+}
+
+func newInstance(items []menu.Food, budget menu.Budget) (*instance, error) {
+	costs, caps, err := menu.Costs(items, budget)
+	if err != nil {
+		return nil, err
+	}
+	in := &instance{items: items, costs: costs, caps: caps}
+	for _, item := range items {
+		in.values = append(in.values, item.Value())
+	}
+	in.order = make([]int, len(items))
+	for i := range in.order {
+		in.order[i] = i
+	}
+	less := menu.NormalizedDensity(budget)
+	sort.SliceStable(in.order, func(a, b int) bool { return less(items[in.order[a]], items[in.order[b]]) })
+	return in, nil
+}
+
+// solution is a selection being searched: the items taken and their
+// total value and cost in each dimension.
+type solution struct {
+	taken []bool
+	loads []float64
+	value float64
+}
+
+func (in *instance) empty() *solution {
+	return &solution{taken: make([]bool, len(in.items)), loads: make([]float64, len(in.caps))}
+}
+
+func (s *solution) copyFrom(t *solution) {
+	copy(s.taken, t.taken)
+	copy(s.loads, t.loads)
+	s.value = t.value
+}
+
+// flip takes item i if s does not, and gives it back if it does.
+func (in *instance) flip(s *solution, i int) {
+	sign := 1.0
+	if s.taken[i] {
+		sign = -1
+	}
+	s.taken[i] = !s.taken[i]
+	s.value += sign * in.values[i]
+	for d, c := range in.costs[i] {
+		s.loads[d] += sign * c
+	}
+}
+
+// fits reports whether item i can be added to s within the budget.
+func (in *instance) fits(s *solution, i int) bool {
+	for d, c := range in.costs[i] {
+		if s.loads[d]+c > in.caps[d] {
+			return false
+		}
+	}
+	return true
+}
+
+func (in *instance) feasible(s *solution) bool {
+	for d, l := range s.loads {
+		if l > in.caps[d] {
+			return false
+		}
+	}
+	return true
+}
+
+// repair gives back the taken items worth 0 or less, and those of lowest
+// density until s fits the budget, then adds the items of highest density
+// that still fit and are worth something. The totals are summed again, so
+// rounding cannot build up over many flips.
+func (in *instance) repair(s *solution) {
+	s.value = 0
+	for d := range s.loads {
+		s.loads[d] = 0
+	}
+	for i, ok := range s.taken {
+		if ok && in.values[i] > 0 {
+			s.taken[i] = false
+			in.flip(s, i)
+		} else {
+			s.taken[i] = false
+		}
+	}
+	for k := len(in.order) - 1; k >= 0 && !in.feasible(s); k-- {
+		if i := in.order[k]; s.taken[i] {
+			in.flip(s, i)
+		}
+	}
+	for _, i := range in.order {
+		if !s.taken[i] && in.values[i] > 0 && in.fits(s, i) {
+			in.flip(s, i)
+		}
+	}
+}
+
+// selection lists the items taken by s in menu order.
+func (in *instance) selection(s *solution, budget menu.Budget) menu.Selection {
+	var taken []menu.Food
+	for i, ok := range s.taken {
+		if ok {
+			taken = append(taken, in.items[i])
+		}
+	}
+	return menu.NewSelection(taken, budget)
+}
+
+// Point is the state of a search after one iteration: the value of the
+// selection it holds and of the best one found so far.
+type Point struct {
+	Iter  int
+	Value float64
+	Best  float64
+}
+
+// Trace records how a search converges. With Every set, only one
+// iteration in Every is kept.
+type Trace struct {
+	Every  int
+	Points []Point
+}
+
+func (t *Trace) record(iter int, value, best float64) {
+	if t == nil || t.Every > 1 && iter%t.Every != 0 {
+		return
+	}
+	t.Points = append(t.Points, Point{iter, value, best})
+}
+
+// WriteCSV writes the trace with columns iter, value and best.
+func (t *Trace) WriteCSV(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "iter,value,best"); err != nil {
+		return err
+	}
+	for _, pt := range t.Points {
+		if _, err := fmt.Fprintf(w, "%d,%g,%g\n", pt.Iter, pt.Value, pt.Best); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package heuristic
+
+import (
+	"math/rand"
+
+	"../menu"
+)
+
+// Tabu search moves to the best selection one flip away that fits, even a
+// worse one, then does not flip that item again for Tenure iterations
+// unless doing so finds a new best. Ties between moves are broken at
+// random.
+type Tabu struct {
+	Seed       int64
+	Iterations int // defaults to 1000
+	Tenure     int // defaults to 7, or a fiftieth of the items if more
+	Trace      *Trace
+}
+
+func (t Tabu) Solve(items []menu.Food, budget menu.Budget) (menu.Selection, error) {
+	in, err := newInstance(items, budget)
+	if err != nil {
+		return menu.Selection{}, err
+	}
+	n := len(items)
+	r := rand.New(rand.NewSource(t.Seed))
+	iterations := t.Iterations
+	if iterations <= 0 {
+		iterations = 1000
+	}
+	tenure := t.Tenure
+	if tenure <= 0 {
+		tenure = 7
+		if n/50 > tenure {
+			tenure = n / 50
+		}
+	}
+
+	cur := in.empty()
+	in.repair(cur)
+	best := in.empty()
+	best.copyFrom(cur)
+	tabuUntil := make([]int, n)
+	for it := 0; it < iterations; it++ {
+		move, moveVal, ties := -1, 0.0, 0
+		for i := 0; i < n; i++ {
+			val := cur.value - in.values[i]
+			if !cur.taken[i] {
+				if !in.fits(cur, i) {
+					continue
+				}
+				val = cur.value + in.values[i]
+			}
+			if tabuUntil[i] > it && val <= best.value {
+				continue
+			}
+			switch {
+			case move < 0 || val > moveVal:
+				move, moveVal, ties = i, val, 1
+			case val == moveVal:
+				// Keep each of the tied moves with equal chance.
+				ties++
+				if r.Intn(ties) == 0 {
+					move = i
+				}
+			}
+		}
+		if move < 0 {
+			break
+		}
+		in.flip(cur, move)
+		tabuUntil[move] = it + 1 + tenure
+		if cur.value > best.value {
+			best.copyFrom(cur)
+		}
+		t.Trace.record(it, cur.value, best.value)
+	}
+	in.repair(best)
+	return in.selection(best, budget), nil
+}
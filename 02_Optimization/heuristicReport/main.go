@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"../heuristic"
+	"../menu"
+)
+
+// instance is a menu to compare the solvers on.
+type instance struct {
+	name   string
+	items  []menu.Food
+	budget menu.Budget
+}
+
+// addDims gives every item random costs in the dimensions w2 to wk, and
+// the budget half of their total in each.
+func addDims(inst *instance, k int, r *rand.Rand) {
+	for d := 2; d <= k; d++ {
+		dim := "w" + strconv.Itoa(d)
+		total := 0.0
+		for i := range inst.items {
+			c := float64(r.Intn(250) + 1)
+			inst.items[i].SetAttr(dim, c)
+			total += c
+		}
+		inst.budget[dim] = float64(int(total / 2))
+	}
+}
+
+// solveExact solves with dp if its table fits, and otherwise runs branch
+// and bound for at most limit.
+func solveExact(inst instance, limit time.Duration) (menu.Anytime, string, error) {
+	sel, err := menu.DynamicProgramming{}.Solve(inst.items, inst.budget)
+	if err == nil {
+		return menu.Anytime{Selection: sel, Bound: sel.Value, Complete: true}, "dp", nil
+	}
+	if !errors.Is(err, menu.ErrTableTooLarge) && !errors.Is(err, menu.ErrFractionalCost) {
+		return menu.Anytime{}, "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), limit)
+	defer cancel()
+	res, err := menu.BranchBound{}.SolveContext(ctx, inst.items, inst.budget)
+	return res, "bnb", err
+}
+
+func writeTrace(fileName string, tr *heuristic.Trace) {
+	f, err := os.Create(fileName)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer f.Close()
+	if err := tr.WriteCSV(f); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func report(inst instance, seed int64, limit time.Duration, tracePrefix string) {
+	fmt.Printf("%s: %d items, budget %v\n", inst.name, len(inst.items), inst.budget)
+	start := time.Now()
+	exact, exactName, err := solveExact(inst, limit)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	exactTime := time.Since(start)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  solver\tvalue\tgap\ttime")
+	if exact.Complete {
+		fmt.Fprintf(tw, "  %s (exact)\t%.0f\t\t%.2fs\n", exactName, exact.Value, exactTime.Seconds())
+	} else {
+		fmt.Fprintf(tw, "  %s (stopped)\t%.0f\t<= %.2f%% of bound %.0f\t%.2fs\n",
+			exactName, exact.Value, 100*exact.RelGap(), exact.Bound, exactTime.Seconds())
+	}
+
+	annealTrace, geneticTrace, tabuTrace := &heuristic.Trace{Every: 100}, &heuristic.Trace{}, &heuristic.Trace{}
+	solvers := []struct {
+		name   string
+		solver menu.Solver
+		trace  *heuristic.Trace
+	}{
+		{"greedy-density", menu.GreedySolver{Order: menu.NormalizedDensity}, nil},
+		{"annealing", heuristic.Annealing{Seed: seed, Trace: annealTrace}, annealTrace},
+		{"genetic", heuristic.Genetic{Seed: seed, Trace: geneticTrace}, geneticTrace},
+		{"tabu", heuristic.Tabu{Seed: seed, Trace: tabuTrace}, tabuTrace},
+	}
+	for _, s := range solvers {
+		start := time.Now()
+		sel, err := s.solver.Solve(inst.items, inst.budget)
+		if err != nil {
+			log.Fatalln(s.name, err)
+		}
+		elapsed := time.Since(start).Seconds()
+		if exact.Complete {
+			fmt.Fprintf(tw, "  %s\t%.0f\t%.2f%%\t%.2fs\n", s.name, sel.Value, gapPercent(exact.Value, sel.Value), elapsed)
+		} else {
+			fmt.Fprintf(tw, "  %s\t%.0f\t<= %.2f%% of bound\t%.2fs\n", s.name, sel.Value, gapPercent(exact.Bound, sel.Value), elapsed)
+		}
+		if tracePrefix != "" && s.trace != nil {
+			writeTrace(fmt.Sprintf("%s-%s-%s.csv", tracePrefix, strings.ReplaceAll(inst.name, " ", "-"), s.name), s.trace)
+		}
+	}
+	tw.Flush()
+	fmt.Println()
+}
+
+// gapPercent is how far value falls short of best, in percent of best, and
+// 0 when best is 0, as when nothing on the menu fits.
+func gapPercent(best, value float64) float64 {
+	if best == 0 {
+		return 0
+	}
+	return 100 * (best - value) / best
+}
+
+func main() {
+	menuPath := flag.String("menu", "", "compare on the menu of a .csv or .json file instead of generated ones")
+	budgetFlag := flag.String("budget", "calories=750", "budget for -menu")
+	families := flag.String("families", "uncorrelated,weakly-correlated,strongly-correlated", "families of the generated menus")
+	sizes := flag.String("n", "50,200,1000,5000", "numbers of items of the generated menus")
+	dims := flag.Int("dims", 1, "number of cost dimensions of the generated menus")
+	seed := flag.Int64("seed", 1, "seed of the generated menus and the heuristics")
+	limit := flag.Duration("limit", 5*time.Second, "time given to branch and bound when dp cannot solve a menu")
+	tracePrefix := flag.String("trace", "", "write the convergence of each heuristic to CSV files with this prefix")
+	flag.Parse()
+
+	if *menuPath != "" {
+		items, err := menu.Load(*menuPath)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		budget, err := menu.ParseBudget(*budgetFlag)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		report(instance{*menuPath, items, budget}, *seed, *limit, *tracePrefix)
+		return
+	}
+
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
+	for _, name := range strings.Split(*families, ",") {
+		family, err := menu.ParseFamily(name)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		for _, s := range strings.Split(*sizes, ",") {
+			n, err := strconv.Atoi(s)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			items, err := menu.Generate(family, n, 250, *seed)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			inst := instance{fmt.Sprintf("%v n=%d", family, n), items, menu.HalfCapacity(items)}
+			addDims(&inst, *dims, r)
+			report(inst, *seed, *limit, *tracePrefix)
+		}
+	}
+}
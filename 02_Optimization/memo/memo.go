@@ -0,0 +1,105 @@
+package memo
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// Stats counts the lookups of a Memo.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+func (s Stats) String() string {
+	rate := 0.0
+	if s.Hits+s.Misses > 0 {
+		rate = 100 * float64(s.Hits) / float64(s.Hits+s.Misses)
+	}
+	return fmt.Sprintf("hits=%d, misses=%d (%.1f%% hit rate), evictions=%d", s.Hits, s.Misses, rate, s.Evictions)
+}
+
+type entry[K comparable, V any] struct {
+	key K
+	val V
+}
+
+// Memo remembers computed values by key. With a capacity it keeps only the
+// most recently used entries. It is safe for concurrent use.
+type Memo[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[K]*list.Element
+	recent   *list.List // of *entry[K, V], most recently used first
+	stats    Stats
+}
+
+// New returns an empty Memo holding at most capacity entries, or any number
+// if capacity is 0.
+func New[K comparable, V any](capacity int) *Memo[K, V] {
+	return &Memo[K, V]{
+		capacity: capacity,
+		entries:  make(map[K]*list.Element),
+		recent:   list.New(),
+	}
+}
+
+// Get returns the value remembered for key.
+func (m *Memo[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok {
+		m.stats.Hits++
+		m.recent.MoveToFront(e)
+		return e.Value.(*entry[K, V]).val, true
+	}
+	m.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Put remembers val for key, evicting the least recently used entry if the
+// Memo is full.
+func (m *Memo[K, V]) Put(key K, val V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok {
+		e.Value.(*entry[K, V]).val = val
+		m.recent.MoveToFront(e)
+		return
+	}
+	m.entries[key] = m.recent.PushFront(&entry[K, V]{key, val})
+	if m.capacity > 0 && m.recent.Len() > m.capacity {
+		oldest := m.recent.Back()
+		m.recent.Remove(oldest)
+		delete(m.entries, oldest.Value.(*entry[K, V]).key)
+		m.stats.Evictions++
+	}
+}
+
+// Do returns the value remembered for key, computing and remembering it with
+// f on a miss. f runs without the lock held, so it may call Do recursively;
+// concurrent misses on one key may each run f.
+func (m *Memo[K, V]) Do(key K, f func() V) V {
+	if val, ok := m.Get(key); ok {
+		return val
+	}
+	val := f()
+	m.Put(key, val)
+	return val
+}
+
+// Len is the number of entries remembered.
+func (m *Memo[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.recent.Len()
+}
+
+func (m *Memo[K, V]) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
@@ -0,0 +1,142 @@
+package memo
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestEvictsLeastRecentlyUsed(t *testing.T) {
+	m := New[string, int](2)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+	if _, ok := m.Get("a"); ok {
+		t.Error("a was not evicted at capacity")
+	}
+	for key, want := range map[string]int{"b": 2, "c": 3} {
+		if got, ok := m.Get(key); !ok || got != want {
+			t.Errorf("Get(%q) = %v, %v, want %v, true", key, got, ok, want)
+		}
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+}
+
+func TestGetMakesRecent(t *testing.T) {
+	m := New[string, int](2)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Get("a")
+	m.Put("c", 3)
+	if _, ok := m.Get("b"); ok {
+		t.Error("b was kept though a was used after it")
+	}
+	if _, ok := m.Get("a"); !ok {
+		t.Error("a was evicted though it was used after b")
+	}
+}
+
+func TestPutMakesRecent(t *testing.T) {
+	m := New[string, int](2)
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("a", 10)
+	m.Put("c", 3)
+	if got, ok := m.Get("a"); !ok || got != 10 {
+		t.Errorf("Get(a) = %v, %v, want 10, true", got, ok)
+	}
+	if _, ok := m.Get("b"); ok {
+		t.Error("b was kept though a was put again after it")
+	}
+}
+
+func TestUnlimited(t *testing.T) {
+	m := New[int, int](0)
+	for i := 0; i < 1000; i++ {
+		m.Put(i, i*i)
+	}
+	if m.Len() != 1000 || m.Stats().Evictions != 0 {
+		t.Errorf("Len() = %d, %v, want 1000 entries and no evictions", m.Len(), m.Stats())
+	}
+}
+
+func TestStats(t *testing.T) {
+	m := New[int, int](1)
+	m.Get(1) // miss
+	m.Put(1, 1)
+	m.Get(1)     // hit
+	m.Put(2, 2)  // evicts 1
+	m.Get(1)     // miss
+	m.Get(2)     // hit
+	m.Do(2, nil) // hit
+	want := Stats{Hits: 3, Misses: 2, Evictions: 1}
+	if got := m.Stats(); got != want {
+		t.Errorf("Stats() = %v, want %v", got, want)
+	}
+	if s := want.String(); s != "hits=3, misses=2 (60.0% hit rate), evictions=1" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestDoComputesOnce(t *testing.T) {
+	m := New[int, int](0)
+	calls := 0
+	f := func() int {
+		calls++
+		return 42
+	}
+	for i := 0; i < 3; i++ {
+		if got := m.Do(7, f); got != 42 {
+			t.Fatalf("Do = %d, want 42", got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("f ran %d times, want once", calls)
+	}
+}
+
+func TestDoRecursive(t *testing.T) {
+	m := New[int, int](0)
+	calls := 0
+	var fib func(n int) int
+	fib = func(n int) int {
+		return m.Do(n, func() int {
+			calls++
+			if n < 2 {
+				return n
+			}
+			return fib(n-1) + fib(n-2)
+		})
+	}
+	if got := fib(50); got != 12586269025 {
+		t.Errorf("fib(50) = %d", got)
+	}
+	if calls != 51 {
+		t.Errorf("computed %d values, want 51", calls)
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	m := New[int, int](16)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if got := m.Do(i%32, func() int { return 2 * (i % 32) }); got != 2*(i%32) {
+					t.Errorf("Do(%d) = %d", i%32, got)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if m.Len() > 16 {
+		t.Errorf("Len() = %d over capacity 16", m.Len())
+	}
+	s := m.Stats()
+	if s.Hits+s.Misses != 8000 {
+		t.Errorf("%v: want 8000 lookups", s)
+	}
+}
@@ -0,0 +1,273 @@
+package menu
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// Anytime is the best selection a search found before it finished or its
+// context ended, with an upper bound on the value of any selection. When
+// Complete the search finished and the selection is optimal.
+type Anytime struct {
+	Selection
+	Bound    float64
+	Complete bool
+	Nodes    int // nodes of the decision tree visited
+}
+
+// Gap is how much more than the selection the optimum may be worth.
+func (a Anytime) Gap() float64 { return a.Bound - a.Value }
+
+// RelGap is Gap as a fraction of Bound.
+func (a Anytime) RelGap() float64 {
+	if a.Bound == 0 {
+		return 0
+	}
+	return a.Gap() / a.Bound
+}
+
+func (a Anytime) String() string {
+	state := "stopped"
+	if a.Complete {
+		state = "complete"
+	}
+	return fmt.Sprintf("%v, bound=%.0f, gap=%.1f%%, %s after %d nodes", a.Selection, a.Bound, 100*a.RelGap(), state, a.Nodes)
+}
+
+// AnytimeSolver is a search that can be stopped by its context. It then
+// still returns the best selection found, and no error.
+type AnytimeSolver interface {
+	SolveContext(ctx context.Context, menu []Food, budget Budget) (Anytime, error)
+}
+
+// checkEvery is how many nodes a search visits between looks at its
+// context.
+const checkEvery = 1024
+
+// anytime is the state shared by the searches of SolveContext: the items
+// taken on the current branch, the best selection found, and the largest
+// bound of a branch left unexplored when the context ended.
+type anytime struct {
+	ctx     context.Context
+	p       *problem
+	ties    bool // break ties between selections as maxVal does
+	taken   []bool
+	best    []bool
+	bestVal float64
+	bound   float64
+	nodes   int
+	stopped bool
+}
+
+func newAnytime(ctx context.Context, p *problem) *anytime {
+	return &anytime{
+		ctx:   ctx,
+		p:     p,
+		taken: make([]bool, len(p.items)),
+		best:  make([]bool, len(p.items)),
+		bound: math.Inf(-1),
+	}
+}
+
+// visit counts a node and reports whether the search must stop.
+func (a *anytime) visit() bool {
+	a.nodes++
+	if !a.stopped && (a.nodes-1)%checkEvery == 0 && a.ctx.Err() != nil {
+		a.stopped = true
+	}
+	return a.stopped
+}
+
+// offer makes the items taken, worth val, the best selection if they are.
+func (a *anytime) offer(val float64) {
+	if val > a.bestVal || a.ties && val == a.bestVal && lexLess(a.taken, a.best) {
+		a.bestVal = val
+		copy(a.best, a.taken)
+	}
+}
+
+// lexLess reports whether a takes nothing that b does not before the first
+// item where they differ.
+func lexLess(a, b []bool) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return b[i]
+		}
+	}
+	return false
+}
+
+// leave records a branch left unexplored that is worth at most bound.
+func (a *anytime) leave(bound float64) {
+	a.bound = math.Max(a.bound, bound)
+}
+
+func (a *anytime) result(budget Budget) Anytime {
+	sel := a.p.selection(a.best, budget)
+	bound := sel.Value
+	if a.stopped {
+		bound = math.Max(bound, a.bound)
+	}
+	return Anytime{sel, bound, !a.stopped, a.nodes}
+}
+
+// maxVal walks the whole decision tree in menu order like maxVal, taking
+// before skipping, so that the first selections found fill the budget.
+func (a *anytime) maxVal(r *relaxation, i int, avail []float64, val float64) {
+	if a.visit() {
+		a.leave(val + r.bound(i, avail))
+		return
+	}
+	if i == len(a.p.items) {
+		a.offer(val)
+		return
+	}
+	if a.p.fits(i, avail) {
+		a.taken[i] = true
+		a.maxVal(r, i+1, a.p.spend(i, avail), val+a.p.items[i].Value())
+		a.taken[i] = false
+		if a.stopped {
+			a.leave(val + r.bound(i+1, avail))
+			return
+		}
+	}
+	a.maxVal(r, i+1, avail, val)
+}
+
+// SolveContext is Solve stopped by ctx, without tracing.
+func (s SearchTree) SolveContext(ctx context.Context, menu []Food, budget Budget) (Anytime, error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return Anytime{}, err
+	}
+	a := newAnytime(ctx, p)
+	a.ties = true
+	a.maxVal(menuRelaxation(p), 0, p.caps, 0)
+	return a.result(budget), nil
+}
+
+// fastMaxVal is fastMaxVal keeping track of the items taken. It returns
+// the best value of items i.. within avail, or false if the search stopped
+// before knowing it.
+func (a *anytime) fastMaxVal(r *relaxation, memo searchMemo, i int, avail []float64, val float64) (float64, bool) {
+	if a.visit() {
+		a.leave(val + r.bound(i, avail))
+		return 0, false
+	}
+	if best, ok := memo.lookup(i, avail); ok {
+		a.complete(memo, i, avail, val, best)
+		return best, true
+	}
+	best := 0.0
+	if i == len(a.p.items) {
+		a.offer(val)
+	} else {
+		best = math.Inf(-1)
+		if a.p.fits(i, avail) {
+			value := a.p.items[i].Value()
+			a.taken[i] = true
+			withVal, ok := a.fastMaxVal(r, memo, i+1, a.p.spend(i, avail), val+value)
+			a.taken[i] = false
+			if !ok {
+				a.leave(val + r.bound(i+1, avail))
+				return 0, false
+			}
+			best = withVal + value
+		}
+		withoutVal, ok := a.fastMaxVal(r, memo, i+1, avail, val)
+		if !ok {
+			return 0, false
+		}
+		best = math.Max(best, withoutVal)
+	}
+	memo.store(i, avail, best)
+	return best, true
+}
+
+// complete offers the items taken together with the best items from i on,
+// worth best, read back from memo. If memo has forgotten some of them, the
+// value is only recorded as a bound.
+func (a *anytime) complete(memo searchMemo, i int, avail []float64, val, best float64) {
+	if val+best <= a.bestVal {
+		return
+	}
+	if a.walk(memo, i, avail) {
+		a.offer(val + best)
+	} else {
+		a.leave(val + best)
+	}
+	for j := i; j < len(a.taken); j++ {
+		a.taken[j] = false
+	}
+}
+
+// walk marks as taken the best items from i on within avail, as read back
+// from memo, and reports whether memo knew all the values needed.
+func (a *anytime) walk(memo searchMemo, i int, avail []float64) bool {
+	for ; i < len(a.p.items); i++ {
+		if !a.p.fits(i, avail) {
+			continue
+		}
+		left := a.p.spend(i, avail)
+		withVal, ok1 := memo.lookup(i+1, left)
+		withoutVal, ok2 := memo.lookup(i+1, avail)
+		if !ok1 || !ok2 {
+			return false
+		}
+		if withVal+a.p.items[i].Value() > withoutVal {
+			a.taken[i] = true
+			avail = left
+		}
+	}
+	return true
+}
+
+// SolveContext is Solve stopped by ctx, without tracing.
+func (s MemoSearch) SolveContext(ctx context.Context, menu []Food, budget Budget) (Anytime, error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return Anytime{}, err
+	}
+	a := newAnytime(ctx, p)
+	memo, avail := newSearchMemo(p, s.Capacity)
+	if _, ok := a.fastMaxVal(menuRelaxation(p), memo, 0, avail, 0); ok {
+		// Read the selection back as Solve does.
+		copy(a.best, p.readBack(memo, avail))
+	}
+	return a.result(budget), nil
+}
+
+// branchBound is the search of BranchBound.
+func (a *anytime) branchBound(r *relaxation, pos int, avail []float64, val float64) {
+	if a.visit() {
+		a.leave(val + r.bound(pos, avail))
+		return
+	}
+	a.offer(val)
+	if pos == len(r.order) || val+r.bound(pos, avail) <= a.bestVal {
+		return
+	}
+	i := r.order[pos]
+	if r.fits(pos, avail) {
+		a.taken[i] = true
+		a.branchBound(r, pos+1, a.p.spend(i, avail), val+r.values[pos])
+		a.taken[i] = false
+		if a.stopped {
+			a.leave(val + r.bound(pos+1, avail))
+			return
+		}
+	}
+	a.branchBound(r, pos+1, avail, val)
+}
+
+// SolveContext is Solve stopped by ctx.
+func (BranchBound) SolveContext(ctx context.Context, menu []Food, budget Budget) (Anytime, error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return Anytime{}, err
+	}
+	a := newAnytime(ctx, p)
+	a.branchBound(newRelaxation(p, budget), 0, p.caps, 0)
+	return a.result(budget), nil
+}
@@ -0,0 +1,122 @@
+package menu
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// stringMemoMaxVal is fastMaxVal as it was before the typed memo tables: a
+// string key built per call and the items taken copied into every entry.
+// It is kept as the baseline of the benchmarks.
+func stringMemoMaxVal(p *problem, i int, avail []float64, memo map[string]stringMemoResult) (float64, []Food) {
+	var taken []Food
+	var val float64
+	key := fmt.Sprintf("len=%d,avail=%v", len(p.items)-i, avail)
+	r, ok := memo[key]
+	if ok {
+		val, taken = r.val, r.taken
+	} else if i == len(p.items) {
+		val, taken = 0, nil
+	} else if !p.fits(i, avail) {
+		val, taken = stringMemoMaxVal(p, i+1, avail, memo)
+	} else {
+		nextItem := p.items[i]
+		withVal, withToTake := stringMemoMaxVal(p, i+1, p.spend(i, avail), memo)
+		withVal += nextItem.Value()
+		withoutVal, withoutToTake := stringMemoMaxVal(p, i+1, avail, memo)
+		if withVal > withoutVal {
+			val, taken = withVal, append(withToTake[:len(withToTake):len(withToTake)], nextItem)
+		} else {
+			val, taken = withoutVal, withoutToTake
+		}
+	}
+	memo[key] = stringMemoResult{val, taken}
+	return val, taken
+}
+
+type stringMemoResult struct {
+	val   float64
+	taken []Food
+}
+
+// benchMenu is buildLargeMenu of food.go with a fixed seed.
+func benchMenu(numItems int) []Food {
+	r := rand.New(rand.NewSource(1))
+	var items []Food
+	for i := 0; i < numItems; i++ {
+		var f Food
+		f.Init(strconv.Itoa(i), float64(r.Intn(90)+1), float64(r.Intn(250)+1))
+		items = append(items, f)
+	}
+	return items
+}
+
+func BenchmarkFastMaxValStringMemo(b *testing.B) {
+	foods := benchMenu(50)
+	budget := CalorieBudget(750)
+	for n := 0; n < b.N; n++ {
+		p, err := newProblem(foods, budget)
+		if err != nil {
+			b.Fatal(err)
+		}
+		stringMemoMaxVal(p, 0, p.caps, make(map[string]stringMemoResult))
+	}
+}
+
+func BenchmarkFastMaxValDenseMemo(b *testing.B) {
+	foods := benchMenu(50)
+	budget := CalorieBudget(750)
+	for n := 0; n < b.N; n++ {
+		if _, err := (MemoSearch{}).Solve(foods, budget); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFastMaxValSparseMemo(b *testing.B) {
+	foods := benchMenu(50)
+	budget := CalorieBudget(750)
+	for n := 0; n < b.N; n++ {
+		p, err := newProblem(foods, budget)
+		if err != nil {
+			b.Fatal(err)
+		}
+		fastMaxVal(p, 0, p.caps, newSparseMemo(0), nil, node{})
+	}
+}
+
+// benchSizes are the menu sizes of BenchmarkSolvers; maxval, whose search
+// tree doubles with every item, only runs the first.
+var benchSizes = []int{20, 60}
+
+// BenchmarkSolvers runs every registered solver on a seeded instance of
+// every family, with half of the total cost as budget.
+func BenchmarkSolvers(b *testing.B) {
+	for _, name := range SolverNames() {
+		solver, err := NewSolver(name)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, family := range Families() {
+			for k, n := range benchSizes {
+				if name == "maxval" && k > 0 {
+					continue
+				}
+				foods, err := Generate(family, n, 100, 1)
+				if err != nil {
+					b.Fatal(err)
+				}
+				budget := HalfCapacity(foods)
+				b.Run(fmt.Sprintf("%s/%v/n=%d", name, family, n), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						if _, err := solver.Solve(foods, budget); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package menu
+
+import (
+	"math"
+	"sort"
+)
+
+// density is value per unit of cost. A free item comes first if it is worth
+// something, last if it is worth less than nothing, and with density 0 if it
+// is worth nothing.
+func density(value, cost float64) float64 {
+	if cost == 0 {
+		switch {
+		case value > 0:
+			return math.Inf(1)
+		case value < 0:
+			return math.Inf(-1)
+		}
+		return 0
+	}
+	return value / cost
+}
+
+// fractionalBound is the value of the LP relaxation in one dimension: fill
+// avail with items in order, splitting the first one that does not fit.
+// items must be sorted by decreasing density in that dimension. Items of
+// value 0 or less, which sort last, are never worth taking.
+func fractionalBound(values, costs []float64, items []int, avail float64) float64 {
+	bound := 0.0
+	for _, i := range items {
+		if values[i] <= 0 {
+			break
+		}
+		if costs[i] <= avail {
+			avail -= costs[i]
+			bound += values[i]
+		} else {
+			bound += values[i] * avail / costs[i]
+			break
+		}
+	}
+	return bound
+}
+
+// relaxation holds the items of a problem in branching order together with
+// their orders by density in each dimension, for computing upper bounds.
+type relaxation struct {
+	p      *problem
+	order  []int       // branching order: index into p.items by position
+	values []float64   // value by position
+	costs  [][]float64 // costs[d][pos]
+	byDim  [][]int     // positions sorted by density in dimension d
+}
+
+// newRelaxation branches in order of decreasing normalized density.
+func newRelaxation(p *problem, budget Budget) *relaxation {
+	order := make([]int, len(p.items))
+	for i := range order {
+		order[i] = i
+	}
+	less := NormalizedDensity(budget)
+	sort.SliceStable(order, func(a, b int) bool {
+		return less(p.items[order[a]], p.items[order[b]])
+	})
+	return relax(p, order)
+}
+
+// menuRelaxation branches in menu order, as maxVal does.
+func menuRelaxation(p *problem) *relaxation {
+	order := make([]int, len(p.items))
+	for i := range order {
+		order[i] = i
+	}
+	return relax(p, order)
+}
+
+func relax(p *problem, order []int) *relaxation {
+	n := len(p.items)
+	r := &relaxation{p: p, order: order, values: make([]float64, n)}
+	for pos, i := range r.order {
+		r.values[pos] = p.items[i].Value()
+	}
+	for d := range p.dims {
+		costs := make([]float64, n)
+		for pos, i := range r.order {
+			costs[pos] = p.costs[i][d]
+		}
+		positions := make([]int, n)
+		for pos := range positions {
+			positions[pos] = pos
+		}
+		sort.SliceStable(positions, func(a, b int) bool {
+			return density(r.values[positions[a]], costs[positions[a]]) > density(r.values[positions[b]], costs[positions[b]])
+		})
+		r.costs = append(r.costs, costs)
+		r.byDim = append(r.byDim, positions)
+	}
+	return r
+}
+
+// bound is an upper bound on the value that the items from position from
+// on can add within avail: the smallest of the fractional bounds of the
+// dimensions.
+func (r *relaxation) bound(from int, avail []float64) float64 {
+	bound := math.Inf(1)
+	rest := make([]int, 0, len(r.order)-from)
+	for d, positions := range r.byDim {
+		rest = rest[:0]
+		for _, pos := range positions {
+			if pos >= from {
+				rest = append(rest, pos)
+			}
+		}
+		bound = math.Min(bound, fractionalBound(r.values, r.costs[d], rest, avail[d]))
+	}
+	return bound
+}
+
+// fits reports whether the item at position pos fits in avail.
+func (r *relaxation) fits(pos int, avail []float64) bool {
+	return r.p.fits(r.order[pos], avail)
+}
+
+// BranchBound searches the decision tree in order of decreasing density and
+// prunes every branch whose fractional bound cannot beat the best found.
+type BranchBound struct{}
+
+func (BranchBound) Solve(menu []Food, budget Budget) (Selection, error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return Selection{}, err
+	}
+	r := newRelaxation(p, budget)
+
+	taken := make([]bool, len(menu))
+	bestTaken := make([]bool, len(menu))
+	bestVal := 0.0
+
+	var search func(pos int, avail []float64, val float64)
+	search = func(pos int, avail []float64, val float64) {
+		if val > bestVal {
+			bestVal = val
+			copy(bestTaken, taken)
+		}
+		if pos == len(r.order) || val+r.bound(pos, avail) <= bestVal {
+			return
+		}
+		i := r.order[pos]
+		if r.fits(pos, avail) {
+			taken[i] = true
+			search(pos+1, p.spend(i, avail), val+r.values[pos])
+			taken[i] = false
+		}
+		search(pos+1, avail, val)
+	}
+	search(0, p.caps, 0)
+
+	return p.selection(bestTaken, budget), nil
+}
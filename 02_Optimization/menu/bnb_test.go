@@ -0,0 +1,106 @@
+package menu
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// testMenu names the foods f0, f1, ... in order.
+func testMenu(t *testing.T, values, costs []float64) []Food {
+	t.Helper()
+	names := make([]string, len(values))
+	for i := range names {
+		names[i] = fmt.Sprint("f", i)
+	}
+	menu, err := BuildMenu(names, values, costs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return menu
+}
+
+// signedMenu is a random menu of n foods with some values 0 or less, some
+// free foods and ties, and a budget of some of the total cost.
+func signedMenu(t *testing.T, r *rand.Rand, n int) ([]Food, Budget) {
+	values := make([]float64, n)
+	costs := make([]float64, n)
+	total := 0
+	for i := range values {
+		values[i] = float64(r.Intn(20) - 5)
+		c := r.Intn(7)
+		costs[i] = float64(c)
+		total += c
+	}
+	return testMenu(t, values, costs), CalorieBudget(float64(r.Intn(total + 1)))
+}
+
+func TestBranchBoundNegativeValues(t *testing.T) {
+	menu := testMenu(t,
+		[]float64{9, -5, -4, 11, 14, 8, -1, 4},
+		[]float64{1, 4, 6, 3, 2, 4, 2, 2})
+	sel, err := BranchBound{}.Solve(menu, CalorieBudget(19))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sel.Value != 46 {
+		t.Errorf("value = %v, want 46: %v", sel.Value, sel)
+	}
+}
+
+func TestBranchBoundMatchesSearchTree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for k := 0; k < 2000; k++ {
+		menu, budget := signedMenu(t, r, 8)
+		want, err := SearchTree{}.Solve(menu, budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := BranchBound{}.Solve(menu, budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Value != want.Value {
+			t.Fatalf("%v within %v: bnb %v, maxval %v", menu, budget, got, want)
+		}
+	}
+}
+
+func TestRelaxationBound(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for k := 0; k < 2000; k++ {
+		menu, budget := signedMenu(t, r, 8)
+		p, err := newProblem(menu, budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opt, err := SearchTree{}.Solve(menu, budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bound := newRelaxation(p, budget).bound(0, p.caps); bound < opt.Value {
+			t.Fatalf("%v within %v: bound %v below the optimum %v", menu, budget, bound, opt.Value)
+		}
+	}
+}
+
+func TestDensityOfFreeFoods(t *testing.T) {
+	menu := testMenu(t, []float64{0, -1, 3, 1, 0, 2}, []float64{0, 0, 1, 0, 2, 2})
+	for _, f := range menu {
+		if math.IsNaN(f.Density()) {
+			t.Errorf("%v: density is NaN", f)
+		}
+	}
+	sorted := append([]Food(nil), menu...)
+	sort.Stable(customSort{sorted, ByDensity})
+	var got []string
+	for _, f := range sorted {
+		got = append(got, f.Name())
+	}
+	want := "[f3 f2 f5 f0 f4 f1]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("by density %v, want %s", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Calories is the cost dimension of Food.Cost. Every other dimension is an
+// extra column of the menu, e.g. "price" or "time".
+const Calories = "calories"
+
+var (
+	ErrEmptyBudget = errors.New("menu: empty budget")
+	ErrMissingCost = errors.New("menu: food has no cost in budget dimension")
+)
+
+// Budget is the most that may be spent in each named cost dimension.
+type Budget map[string]float64
+
+// CalorieBudget is the one-dimensional budget of the lectures.
+func CalorieBudget(calories float64) Budget {
+	return Budget{Calories: calories}
+}
+
+// ParseBudget reads a budget written as "calories=750,price=20".
+func ParseBudget(s string) (Budget, error) {
+	budget := make(Budget)
+	for _, part := range strings.Split(s, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("menu: budget %q: want key=value", part)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if err != nil {
+			return nil, fmt.Errorf("menu: budget %q: %v", part, err)
+		}
+		budget[strings.ToLower(strings.TrimSpace(key))] = v
+	}
+	return budget, nil
+}
+
+// Dims lists the dimensions of the budget in sorted order.
+func (b Budget) Dims() []string {
+	var dims []string
+	for d := range b {
+		dims = append(dims, d)
+	}
+	sort.Strings(dims)
+	return dims
+}
+
+func (b Budget) String() string {
+	var parts []string
+	for _, d := range b.Dims() {
+		parts = append(parts, fmt.Sprintf("%s=%v", d, b[d]))
+	}
+	return strings.Join(parts, ",")
+}
+
+// CostOf returns the cost of the food in dimension dim.
+func (f Food) CostOf(dim string) (float64, bool) {
+	if dim == Calories {
+		return f.calories, true
+	}
+	return f.Attr(dim)
+}
+
+// problem is a menu and budget checked and laid out for the solvers: the
+// cost of item i in dimension d is costs[i][d] and its limit is caps[d].
+type problem struct {
+	items []Food
+	dims  []string
+	costs [][]float64
+	caps  []float64
+}
+
+func newProblem(menu []Food, budget Budget) (*problem, error) {
+	if len(budget) == 0 {
+		return nil, ErrEmptyBudget
+	}
+	p := &problem{items: menu, dims: budget.Dims()}
+	for _, d := range p.dims {
+		if budget[d] < 0 {
+			return nil, fmt.Errorf("%w: %s=%v", ErrNegativeBudget, d, budget[d])
+		}
+		p.caps = append(p.caps, budget[d])
+	}
+	for _, item := range menu {
+		costs := make([]float64, len(p.dims))
+		for j, d := range p.dims {
+			c, ok := item.CostOf(d)
+			if !ok {
+				return nil, fmt.Errorf("%w %s: %s", ErrMissingCost, d, item)
+			}
+			if c < 0 {
+				return nil, fmt.Errorf("%w: %s=%v for %s", ErrNegativeCost, d, c, item)
+			}
+			costs[j] = c
+		}
+		p.costs = append(p.costs, costs)
+	}
+	return p, nil
+}
+
+// Costs checks menu and budget as every solver of the package does, and
+// lays them out as the solvers do: the cost of item i in dimension d is
+// costs[i][d] and its limit is caps[d], with the dimensions in the order of
+// budget.Dims.
+func Costs(menu []Food, budget Budget) (costs [][]float64, caps []float64, err error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return nil, nil, err
+	}
+	return p.costs, p.caps, nil
+}
+
+// newWholeProblem additionally requires whole-number costs, as the table
+// based solvers index by remaining capacity.
+func newWholeProblem(menu []Food, budget Budget) (*problem, error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.whole(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// whole reports the first cost of p that is not a whole number.
+func (p *problem) whole() error {
+	for i, costs := range p.costs {
+		for j, c := range costs {
+			if c != math.Trunc(c) {
+				return fmt.Errorf("%w: %s=%v for %s", ErrFractionalCost, p.dims[j], c, p.items[i])
+			}
+		}
+	}
+	return nil
+}
+
+// fits reports whether item i fits in avail.
+func (p *problem) fits(i int, avail []float64) bool {
+	for d, c := range p.costs[i] {
+		if c > avail[d] {
+			return false
+		}
+	}
+	return true
+}
+
+// spend returns avail less the cost of item i.
+func (p *problem) spend(i int, avail []float64) []float64 {
+	left := make([]float64, len(avail))
+	for d, c := range p.costs[i] {
+		left[d] = avail[d] - c
+	}
+	return left
+}
+
+// load is the normalized cost of item i: the sum over dimensions of the
+// share of the budget it uses. With a single dimension it orders items the
+// same way as their plain cost.
+func (p *problem) load(i int) float64 {
+	total := 0.0
+	for d, c := range p.costs[i] {
+		if p.caps[d] > 0 {
+			total += c / p.caps[d]
+		} else if c > 0 {
+			return math.Inf(1)
+		}
+	}
+	return total
+}
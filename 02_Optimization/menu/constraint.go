@@ -0,0 +1,502 @@
+package menu
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownFood   = errors.New("menu: unknown food")
+	ErrNegativeLimit = errors.New("menu: negative at-most limit")
+	ErrContradiction = errors.New("menu: contradictory rules")
+	ErrBrokenRule    = errors.New("menu: selection breaks a rule")
+)
+
+// Constraints are rules between the foods of a menu, by name: a food that
+// requires others, pairs of foods that exclude each other, and groups of
+// which at most k foods may be taken.
+type Constraints struct {
+	requires [][2]string
+	excludes [][2]string
+	groups   []group
+}
+
+type group struct {
+	k     int
+	names []string
+}
+
+// Require adds the rule that food is only taken with every one of needs.
+func (c *Constraints) Require(food string, needs ...string) {
+	for _, n := range needs {
+		c.requires = append(c.requires, [2]string{food, n})
+	}
+}
+
+// Exclude adds the rule that a and b are never both taken.
+func (c *Constraints) Exclude(a, b string) {
+	c.excludes = append(c.excludes, [2]string{a, b})
+}
+
+// AtMost adds the rule that at most k of names are taken.
+func (c *Constraints) AtMost(k int, names ...string) {
+	c.groups = append(c.groups, group{k, names})
+}
+
+// String writes the rules in the format read by ParseConstraints.
+func (c *Constraints) String() string {
+	var b strings.Builder
+	for _, r := range c.requires {
+		fmt.Fprintf(&b, "requires %s %s\n", r[0], r[1])
+	}
+	for _, e := range c.excludes {
+		fmt.Fprintf(&b, "excludes %s %s\n", e[0], e[1])
+	}
+	for _, g := range c.groups {
+		fmt.Fprintf(&b, "atmost %d %s\n", g.k, strings.Join(g.names, " "))
+	}
+	return b.String()
+}
+
+// ParseConstraints reads one rule per line:
+//
+//	requires burger fries
+//	excludes cake donut
+//	atmost 1 wine beer cola
+//
+// Blank lines and lines starting with # are skipped. Bad lines are reported
+// together as RowErrors numbered by line.
+func ParseConstraints(r io.Reader) (*Constraints, error) {
+	c := &Constraints{}
+	var errs []error
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		var err error
+		switch fields[0] {
+		case "requires":
+			if len(fields) < 3 {
+				err = fmt.Errorf("%w: want requires food needed...", ErrFieldCount)
+				break
+			}
+			c.Require(fields[1], fields[2:]...)
+		case "excludes":
+			if len(fields) != 3 {
+				err = fmt.Errorf("%w: want excludes food food", ErrFieldCount)
+				break
+			}
+			c.Exclude(fields[1], fields[2])
+		case "atmost":
+			if len(fields) < 3 {
+				err = fmt.Errorf("%w: want atmost k food...", ErrFieldCount)
+				break
+			}
+			k, perr := strconv.Atoi(fields[1])
+			if perr != nil {
+				err = perr
+				break
+			}
+			c.AtMost(k, fields[2:]...)
+		default:
+			err = fmt.Errorf("unknown rule %q, want requires, excludes or atmost", fields[0])
+		}
+		if err != nil {
+			errs = append(errs, &RowError{line, err})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return c, nil
+}
+
+// LoadConstraints reads rules from a file in the format of
+// ParseConstraints.
+func LoadConstraints(path string) (*Constraints, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseConstraints(f)
+}
+
+// rules are Constraints compiled against a menu, by item index.
+type rules struct {
+	needs    [][]int // items each item requires
+	excl     [][]int // items each item excludes
+	inGroups [][]int // groups each item is in
+	limits   []int   // at-most limit of each group
+	groups   []string
+	closure  [][]int // each item and everything it requires, directly or not
+}
+
+// compile checks c against menu. Unknown foods and negative limits are
+// errors, and so is a food that can never be taken because what it
+// requires breaks another rule.
+func (c *Constraints) compile(menu []Food) (*rules, error) {
+	index := make(map[string]int)
+	for i, item := range menu {
+		if _, ok := index[item.Name()]; ok {
+			return nil, fmt.Errorf("menu: %w %q", ErrDuplicateName, item.Name())
+		}
+		index[item.Name()] = i
+	}
+	var errs []error
+	lookup := func(name string) int {
+		i, ok := index[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w %q", ErrUnknownFood, name))
+			return -1
+		}
+		return i
+	}
+
+	n := len(menu)
+	r := &rules{needs: make([][]int, n), excl: make([][]int, n), inGroups: make([][]int, n)}
+	if c == nil {
+		c = &Constraints{}
+	}
+	for _, req := range c.requires {
+		if i, j := lookup(req[0]), lookup(req[1]); i >= 0 && j >= 0 && i != j {
+			r.needs[i] = append(r.needs[i], j)
+		}
+	}
+	for _, ex := range c.excludes {
+		if i, j := lookup(ex[0]), lookup(ex[1]); i >= 0 && j >= 0 {
+			r.excl[i] = append(r.excl[i], j)
+			if i != j {
+				r.excl[j] = append(r.excl[j], i)
+			}
+		}
+	}
+	for g, grp := range c.groups {
+		if grp.k < 0 {
+			errs = append(errs, fmt.Errorf("%w %d for %s", ErrNegativeLimit, grp.k, strings.Join(grp.names, ", ")))
+		}
+		r.limits = append(r.limits, grp.k)
+		r.groups = append(r.groups, strings.Join(grp.names, ", "))
+		seen := make(map[int]bool)
+		for _, name := range grp.names {
+			if i := lookup(name); i >= 0 && !seen[i] {
+				seen[i] = true
+				r.inGroups[i] = append(r.inGroups[i], g)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	for i := range menu {
+		r.closure = append(r.closure, r.required(i))
+		if err := r.contradiction(menu, i); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return r, nil
+}
+
+// required returns i and everything it requires, in menu order.
+func (r *rules) required(i int) []int {
+	seen := map[int]bool{i: true}
+	stack := []int{i}
+	for len(stack) > 0 {
+		j := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, k := range r.needs[j] {
+			if !seen[k] {
+				seen[k] = true
+				stack = append(stack, k)
+			}
+		}
+	}
+	var closure []int
+	for j := range seen {
+		closure = append(closure, j)
+	}
+	sort.Ints(closure)
+	return closure
+}
+
+// contradiction explains why item i can never be taken because of what it
+// requires, or returns nil. A food excluded by itself or in a group of at
+// most 0 is simply never taken.
+func (r *rules) contradiction(menu []Food, i int) error {
+	if len(r.closure[i]) == 1 {
+		return nil
+	}
+	in := make(map[int]bool)
+	for _, j := range r.closure[i] {
+		in[j] = true
+	}
+	var needs []string
+	for _, j := range r.closure[i] {
+		if j != i {
+			needs = append(needs, menu[j].Name())
+		}
+	}
+	taking := menu[i].Name() + " and the " + strings.Join(needs, ", ") + " it requires"
+	for _, j := range r.closure[i] {
+		for _, k := range r.excl[j] {
+			if in[k] {
+				return fmt.Errorf("%w: %s can never be taken: taking %s breaks %s excludes %s",
+					ErrContradiction, menu[i].Name(), taking, menu[j].Name(), menu[k].Name())
+			}
+		}
+	}
+	counts := make([]int, len(r.limits))
+	for _, j := range r.closure[i] {
+		for _, g := range r.inGroups[j] {
+			counts[g]++
+			if counts[g] > r.limits[g] {
+				return fmt.Errorf("%w: %s can never be taken: taking %s breaks at most %d of %s",
+					ErrContradiction, menu[i].Name(), taking, r.limits[g], r.groups[g])
+			}
+		}
+	}
+	return nil
+}
+
+// Check reports every rule that taking items from menu breaks.
+func (c *Constraints) Check(menu []Food, items []Food) error {
+	r, err := c.compile(menu)
+	if err != nil {
+		return err
+	}
+	taken := make([]bool, len(menu))
+	for _, item := range items {
+		for i := range menu {
+			if menu[i].Name() == item.Name() {
+				taken[i] = true
+			}
+		}
+	}
+	var errs []error
+	counts := make([]int, len(r.limits))
+	for i, ok := range taken {
+		if !ok {
+			continue
+		}
+		for _, j := range r.needs[i] {
+			if !taken[j] {
+				errs = append(errs, fmt.Errorf("%w: %s without %s", ErrBrokenRule, menu[i].Name(), menu[j].Name()))
+			}
+		}
+		for _, j := range r.excl[i] {
+			if taken[j] && j >= i {
+				errs = append(errs, fmt.Errorf("%w: %s with %s", ErrBrokenRule, menu[i].Name(), menu[j].Name()))
+			}
+		}
+		for _, g := range r.inGroups[i] {
+			counts[g]++
+		}
+	}
+	for g, n := range counts {
+		if n > r.limits[g] {
+			errs = append(errs, fmt.Errorf("%w: %d of %s, at most %d", ErrBrokenRule, n, r.groups[g], r.limits[g]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ConstrainedSearch is maxVal under Rules: a branch ends as soon as it
+// breaks a rule, or when even all the remaining value could not beat the
+// best selection found.
+type ConstrainedSearch struct {
+	Rules *Constraints
+}
+
+type constrainedSearch struct {
+	p       *problem
+	r       *rules
+	rest    []float64 // value of the items from i on
+	taken   []bool
+	needed  []int // taken items requiring each item
+	counts  []int // taken items of each group
+	best    []bool
+	bestVal float64
+}
+
+func (s ConstrainedSearch) Solve(menu []Food, budget Budget) (Selection, error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return Selection{}, err
+	}
+	r, err := s.Rules.compile(menu)
+	if err != nil {
+		return Selection{}, err
+	}
+	cs := &constrainedSearch{
+		p:       p,
+		r:       r,
+		rest:    make([]float64, len(menu)+1),
+		taken:   make([]bool, len(menu)),
+		needed:  make([]int, len(menu)),
+		counts:  make([]int, len(r.limits)),
+		best:    make([]bool, len(menu)),
+		bestVal: math.Inf(-1),
+	}
+	for i := len(menu) - 1; i >= 0; i-- {
+		cs.rest[i] = cs.rest[i+1] + math.Max(0, menu[i].Value())
+	}
+	cs.search(0, p.caps, 0)
+	return p.selection(cs.best, budget), nil
+}
+
+func (s *constrainedSearch) search(i int, avail []float64, val float64) {
+	if i == len(s.p.items) {
+		if val > s.bestVal {
+			s.bestVal = val
+			copy(s.best, s.taken)
+		}
+		return
+	}
+	if val+s.rest[i] <= s.bestVal {
+		return
+	}
+	if s.canTake(i, avail) {
+		s.take(i, true)
+		s.search(i+1, s.p.spend(i, avail), val+s.p.items[i].Value())
+		s.take(i, false)
+	}
+	if s.needed[i] == 0 {
+		s.search(i+1, avail, val)
+	}
+}
+
+// canTake reports whether taking item i keeps to the budget and the rules,
+// given the decisions on the items before it.
+func (s *constrainedSearch) canTake(i int, avail []float64) bool {
+	if !s.p.fits(i, avail) {
+		return false
+	}
+	for _, j := range s.r.needs[i] {
+		if j < i && !s.taken[j] {
+			return false
+		}
+	}
+	for _, j := range s.r.excl[i] {
+		if s.taken[j] || j == i {
+			return false
+		}
+	}
+	for _, g := range s.r.inGroups[i] {
+		if s.counts[g] >= s.r.limits[g] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *constrainedSearch) take(i int, ok bool) {
+	d := 1
+	if !ok {
+		d = -1
+	}
+	s.taken[i] = ok
+	for _, j := range s.r.needs[i] {
+		s.needed[j] += d
+	}
+	for _, g := range s.r.inGroups[i] {
+		s.counts[g] += d
+	}
+}
+
+// ConstrainedGreedy is Greedy under rules: each item is taken together with
+// everything it requires, if all of that fits and breaks no rule.
+func ConstrainedGreedy(items []Food, budget Budget, compFunc CompFunction, c *Constraints) ([]Food, float64, error) {
+	p, err := newProblem(items, budget)
+	if err != nil {
+		return nil, 0, err
+	}
+	r, err := c.compile(items)
+	if err != nil {
+		return nil, 0, err
+	}
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return compFunc(items[order[a]], items[order[b]]) })
+
+	taken := make([]bool, len(items))
+	counts := make([]int, len(r.limits))
+	avail := p.caps
+	var result []Food
+	totalValue := 0.0
+	for _, i := range order {
+		if taken[i] {
+			continue
+		}
+		var bundle []int
+		for _, j := range r.closure[i] {
+			if !taken[j] {
+				bundle = append(bundle, j)
+			}
+		}
+		left, ok := avail, true
+		added := make([]int, len(counts))
+		for _, j := range bundle {
+			if !p.fits(j, left) {
+				ok = false
+				break
+			}
+			left = p.spend(j, left)
+			for _, k := range r.excl[j] {
+				if taken[k] || k == j {
+					ok = false
+				}
+			}
+			for _, g := range r.inGroups[j] {
+				added[g]++
+				if counts[g]+added[g] > r.limits[g] {
+					ok = false
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		avail = left
+		for g := range counts {
+			counts[g] += added[g]
+		}
+		for _, j := range bundle {
+			taken[j] = true
+			result = append(result, items[j])
+			totalValue += items[j].Value()
+		}
+	}
+	return result, totalValue, nil
+}
+
+// ConstrainedGreedySolver is ConstrainedGreedy in the order built by Order.
+type ConstrainedGreedySolver struct {
+	Order Ordering
+	Rules *Constraints
+}
+
+func (g ConstrainedGreedySolver) Solve(menu []Food, budget Budget) (Selection, error) {
+	taken, _, err := ConstrainedGreedy(menu, budget, g.Order(budget), g.Rules)
+	if err != nil {
+		return Selection{}, err
+	}
+	return NewSelection(taken, budget), nil
+}
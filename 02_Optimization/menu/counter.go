@@ -0,0 +1,119 @@
+package menu
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Counterexample is a menu and calorie budget on which a greedy strategy
+// takes less than the optimum found by maxVal.
+type Counterexample struct {
+	Strategy string
+	Source   string // how the menu was made
+	Menu     []Food
+	Budget   Budget
+	Greedy   float64
+	Optimum  float64
+}
+
+// Ratio is the value greedy takes as a fraction of the optimum.
+func (c Counterexample) Ratio() float64 {
+	if c.Optimum == 0 {
+		return 1
+	}
+	return c.Greedy / c.Optimum
+}
+
+func (c Counterexample) String() string {
+	return fmt.Sprintf("greedy by %s takes %g of %g (%.1f%%) within %v from %s: %v",
+		c.Strategy, c.Greedy, c.Optimum, 100*c.Ratio(), c.Budget, c.Source, c.Menu)
+}
+
+// Compare solves menu within budget greedily by s and with maxVal. Only
+// menus small enough for maxVal should be compared.
+func Compare(s GreedyStrategy, source string, menu []Food, budget Budget) (Counterexample, error) {
+	opt, err := SearchTree{}.Solve(menu, budget)
+	if err != nil {
+		return Counterexample{}, err
+	}
+	_, val := Greedy(menu, budget, s.Order(budget))
+	return Counterexample{s.Name, source, menu, budget, val, opt.Value}, nil
+}
+
+// Minimize drops foods from the menu of c, one at a time, and then lowers
+// the budget to what the optimum costs, for as long as greedy still does
+// no better against the optimum. What is left is a counterexample no food
+// of which can be dropped.
+func Minimize(c Counterexample, s GreedyStrategy) (Counterexample, error) {
+	for dropped := true; dropped; {
+		dropped = false
+		for i := range c.Menu {
+			menu := append(append([]Food(nil), c.Menu[:i]...), c.Menu[i+1:]...)
+			d, err := Compare(s, c.Source, menu, c.Budget)
+			if err != nil {
+				return c, err
+			}
+			if d.Greedy < d.Optimum && d.Ratio() <= c.Ratio() {
+				c, dropped = d, true
+				break
+			}
+		}
+	}
+	opt, err := SearchTree{}.Solve(c.Menu, c.Budget)
+	if err != nil {
+		return c, err
+	}
+	if d, err := Compare(s, c.Source, c.Menu, CalorieBudget(opt.Cost)); err != nil {
+		return c, err
+	} else if d.Greedy < d.Optimum && d.Ratio() <= c.Ratio() {
+		c = d
+	}
+	return c, nil
+}
+
+// RandomMenu draws n foods with values and calories from [1, R], named "a",
+// "b" and so on, and a budget between the cheapest food and all of them.
+func RandomMenu(r *rand.Rand, n, R int) ([]Food, Budget) {
+	var menu []Food
+	least, total := R, 0
+	for i := 0; i < n; i++ {
+		cost := r.Intn(R) + 1
+		var f Food
+		f.Init(string(rune('a'+i%26))+suffix(i/26), float64(r.Intn(R)+1), float64(cost))
+		menu = append(menu, f)
+		if cost < least {
+			least = cost
+		}
+		total += cost
+	}
+	return menu, CalorieBudget(float64(least + r.Intn(total-least+1)))
+}
+
+func suffix(k int) string {
+	if k == 0 {
+		return ""
+	}
+	return fmt.Sprint(k)
+}
+
+// Worst keeps the k counterexamples with the lowest ratio.
+type Worst struct {
+	K     int
+	Cases []Counterexample
+}
+
+// Offer keeps c if it is among the k worst so far.
+func (w *Worst) Offer(c Counterexample) {
+	if c.Greedy >= c.Optimum {
+		return
+	}
+	if len(w.Cases) == w.K && c.Ratio() >= w.Cases[len(w.Cases)-1].Ratio() {
+		return
+	}
+	w.Cases = append(w.Cases, c)
+	sort.SliceStable(w.Cases, func(i, j int) bool { return w.Cases[i].Ratio() < w.Cases[j].Ratio() })
+	if len(w.Cases) > w.K {
+		w.Cases = w.Cases[:w.K]
+	}
+}
@@ -0,0 +1,126 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxTableCells bounds the size of the tables of DynamicProgramming.
+const maxTableCells = 1 << 25
+
+var ErrTableTooLarge = errors.New("menu: budget too large for a table")
+
+// table numbers every vector of remaining capacities 0 <= c[d] <= caps[d]
+// as a mixed-radix state, with dimension d at stride strides[d].
+type table struct {
+	caps    []int
+	strides []int
+	states  int
+}
+
+func newTable(p *problem) (*table, error) {
+	t := &table{states: 1}
+	for _, c := range p.caps {
+		c := int(math.Floor(c))
+		t.caps = append(t.caps, c)
+		t.strides = append(t.strides, t.states)
+		if t.states > maxTableCells/(c+1) {
+			return nil, fmt.Errorf("%w: %v", ErrTableTooLarge, p.caps)
+		}
+		t.states *= c + 1
+	}
+	if t.states > maxTableCells/(len(p.items)+1) {
+		return nil, fmt.Errorf("%w: %d items and %d states", ErrTableTooLarge, len(p.items), t.states)
+	}
+	return t, nil
+}
+
+// full is the state with all of the budget left.
+func (t *table) full() int { return t.states - 1 }
+
+// offset is how far item i moves the state when taken.
+func (t *table) offset(p *problem, i int) int {
+	off := 0
+	for d, c := range p.costs[i] {
+		off += int(c) * t.strides[d]
+	}
+	return off
+}
+
+// fits reports whether item i fits in state s.
+func (t *table) fits(p *problem, i int, s int) bool {
+	for d, c := range p.costs[i] {
+		if (s/t.strides[d])%(t.caps[d]+1) < int(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// DynamicProgramming fills the table of best values bottom-up, one row per
+// suffix of the menu and one column per vector of remaining budget. Costs
+// must be whole numbers.
+type DynamicProgramming struct{}
+
+func (DynamicProgramming) Solve(menu []Food, budget Budget) (Selection, error) {
+	p, err := newWholeProblem(menu, budget)
+	if err != nil {
+		return Selection{}, err
+	}
+	t, err := newTable(p)
+	if err != nil {
+		return Selection{}, err
+	}
+	taken := zeroOne(p, t, p.values())
+	return p.selection(taken, budget), nil
+}
+
+// values lists the value of each item of p.
+func (p *problem) values() []float64 {
+	values := make([]float64, len(p.items))
+	for i, item := range p.items {
+		values[i] = item.Value()
+	}
+	return values
+}
+
+// zeroOne solves the 0/1 problem p on table t, item i being worth values[i],
+// and returns which items are taken.
+func zeroOne(p *problem, t *table, values []float64) []bool {
+	best := zeroOneTable(p, t, values)
+	return backtrack(p, t, best, t.full())
+}
+
+// zeroOneTable fills best[i][s], the best value of items i.. within state s.
+func zeroOneTable(p *problem, t *table, values []float64) [][]float64 {
+	n := len(p.items)
+	best := make([][]float64, n+1)
+	best[n] = make([]float64, t.states)
+	for i := n - 1; i >= 0; i-- {
+		best[i] = make([]float64, t.states)
+		off := t.offset(p, i)
+		for s := 0; s < t.states; s++ {
+			best[i][s] = best[i+1][s]
+			if t.fits(p, i, s) {
+				withVal := best[i+1][s-off] + values[i]
+				if withVal > best[i][s] {
+					best[i][s] = withVal
+				}
+			}
+		}
+	}
+	return best
+}
+
+// backtrack reads the items taken for state s out of a zeroOneTable.
+func backtrack(p *problem, t *table, best [][]float64, s int) []bool {
+	taken := make([]bool, len(p.items))
+	for i := range p.items {
+		if best[i][s] != best[i+1][s] {
+			taken[i] = true
+			s -= t.offset(p, i)
+		}
+	}
+	return taken
+}
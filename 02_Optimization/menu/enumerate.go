@@ -0,0 +1,205 @@
+package menu
+
+import (
+	"container/heap"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Alternatives are selections of a menu ordered by decreasing value, then
+// increasing cost. Cost is the cost over all dimensions, each as a
+// fraction of its budget. Truncated reports that more selections qualified
+// than were kept.
+type Alternatives struct {
+	Budget     Budget
+	Selections []Selection
+	Truncated  bool
+}
+
+// TopK lists the k best distinct selections of menu within budget.
+func TopK(menu []Food, budget Budget, k int) (Alternatives, error) {
+	return enumerate(menu, budget, k, func(float64) float64 { return math.Inf(-1) })
+}
+
+// WithinPercent lists the selections of menu within budget worth no more
+// than pct percent less than the optimum, keeping the best limit of them if
+// limit > 0.
+func WithinPercent(menu []Food, budget Budget, pct float64, limit int) (Alternatives, error) {
+	if limit <= 0 {
+		limit = math.MaxInt
+	}
+	return enumerate(menu, budget, limit, func(opt float64) float64 { return opt * (1 - pct/100) })
+}
+
+// candidate is a selection found by the enumeration.
+type candidate struct {
+	taken []bool
+	value float64
+	cost  float64
+}
+
+// better orders candidates by value, then cost, then by taking an item
+// where the other does not at the first item where they differ.
+func (c candidate) better(d candidate) bool {
+	if c.value != d.value {
+		return c.value > d.value
+	}
+	if c.cost != d.cost {
+		return c.cost < d.cost
+	}
+	return lexLess(d.taken, c.taken)
+}
+
+// worstFirst is a heap of candidates with the worst on top.
+type worstFirst []candidate
+
+func (h worstFirst) Len() int            { return len(h) }
+func (h worstFirst) Less(i, j int) bool  { return h[j].better(h[i]) }
+func (h worstFirst) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *worstFirst) Push(x interface{}) { *h = append(*h, x.(candidate)) }
+func (h *worstFirst) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// enumerator walks the decision tree keeping the k best selections worth
+// at least min, and prunes every branch that the table of best values of
+// DynamicProgramming shows cannot reach the worst of them.
+type enumerator struct {
+	p         *problem
+	t         *table
+	best      [][]float64
+	weights   []float64 // cost of each item, as fractions of the budget
+	k         int
+	min       float64
+	taken     []bool
+	found     worstFirst
+	truncated bool
+}
+
+func enumerate(menu []Food, budget Budget, k int, min func(opt float64) float64) (Alternatives, error) {
+	p, err := newWholeProblem(menu, budget)
+	if err != nil {
+		return Alternatives{}, err
+	}
+	t, err := newTable(p)
+	if err != nil {
+		return Alternatives{}, err
+	}
+	e := &enumerator{
+		p:     p,
+		t:     t,
+		best:  zeroOneTable(p, t, p.values()),
+		k:     k,
+		taken: make([]bool, len(menu)),
+	}
+	e.min = min(e.best[0][t.full()])
+	for i := range menu {
+		w := 0.0
+		for d, c := range p.costs[i] {
+			if p.caps[d] > 0 {
+				w += c / p.caps[d]
+			}
+		}
+		e.weights = append(e.weights, w)
+	}
+	if k > 0 {
+		e.search(0, t.full(), 0, 0)
+	}
+
+	sort.Slice(e.found, func(i, j int) bool { return e.found[i].better(e.found[j]) })
+	alts := Alternatives{Budget: budget, Truncated: e.truncated}
+	for _, c := range e.found {
+		alts.Selections = append(alts.Selections, p.selection(c.taken, budget))
+	}
+	return alts, nil
+}
+
+// threshold is the least value a selection must have to be kept.
+func (e *enumerator) threshold() float64 {
+	if len(e.found) < e.k {
+		return e.min
+	}
+	return math.Max(e.min, e.found[0].value)
+}
+
+func (e *enumerator) search(i, s int, val, cost float64) {
+	if reach := val + e.best[i][s]; reach < e.threshold() {
+		// The branch still holds a selection worth reach, only not
+		// one of the best k.
+		if reach >= e.min {
+			e.truncated = true
+		}
+		return
+	}
+	if i == len(e.p.items) {
+		e.keep(candidate{value: val, cost: cost})
+		return
+	}
+	if e.t.fits(e.p, i, s) {
+		e.taken[i] = true
+		e.search(i+1, s-e.t.offset(e.p, i), val+e.p.items[i].Value(), cost+e.weights[i])
+		e.taken[i] = false
+	}
+	e.search(i+1, s, val, cost)
+}
+
+func (e *enumerator) keep(c candidate) {
+	c.taken = e.taken
+	if len(e.found) == e.k {
+		e.truncated = true
+		if !c.better(e.found[0]) {
+			return
+		}
+		heap.Pop(&e.found)
+	}
+	c.taken = append([]bool(nil), c.taken...)
+	heap.Push(&e.found, c)
+}
+
+// Write prints the alternatives as a table, marking with + the items that
+// the best selection does not take and listing with - those it takes that
+// the alternative does not.
+func (a Alternatives) Write(w io.Writer) error {
+	if len(a.Selections) == 0 {
+		_, err := fmt.Fprintf(w, "budget %v: no selections\n", a.Budget)
+		return err
+	}
+	best := a.Selections[0]
+	fmt.Fprintf(w, "budget %v: %d selections\n", a.Budget, len(a.Selections))
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  rank\tvalue\tcosts\titems\tleft out")
+	for r, sel := range a.Selections {
+		extra, missing := diffItems(sel.Items, best.Items)
+		var items []string
+		for _, item := range sel.Items {
+			name := item.Name()
+			for _, x := range extra {
+				if x.Name() == name {
+					name = "+" + name
+					break
+				}
+			}
+			items = append(items, name)
+		}
+		var left []string
+		for _, item := range missing {
+			left = append(left, "-"+item.Name())
+		}
+		fmt.Fprintf(tw, "  %d\t%.4g\t%v\t%s\t%s\n", r+1, sel.Value, sel.Costs, strings.Join(items, " "), strings.Join(left, " "))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if a.Truncated {
+		_, err := fmt.Fprintln(w, "  (more selections qualify)")
+		return err
+	}
+	return nil
+}
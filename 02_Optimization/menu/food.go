@@ -0,0 +1,74 @@
+package menu
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Food Module
+type Food struct {
+	name     string
+	value    float64
+	calories float64
+	attrs    map[string]float64 // extra columns of a menu file
+}
+
+func (f *Food) Init(name string, value float64, calories float64) {
+	f.name = name
+	f.value = value
+	f.calories = calories
+}
+
+func (f Food) Name() string { return f.name }
+
+func (f Food) Value() float64 { return f.value }
+
+func (f Food) Cost() float64 { return f.calories }
+
+// Density is value per calorie, ordered for free foods as density orders them.
+func (f Food) Density() float64 { return density(f.value, f.calories) }
+
+// Attr returns the extra column key of the food, if it has one.
+func (f Food) Attr(key string) (float64, bool) {
+	v, ok := f.attrs[key]
+	return v, ok
+}
+
+// AttrKeys lists the extra columns of the food in sorted order.
+func (f Food) AttrKeys() []string {
+	var keys []string
+	for k := range f.attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *Food) SetAttr(key string, v float64) {
+	if f.attrs == nil {
+		f.attrs = make(map[string]float64)
+	}
+	f.attrs[key] = v
+}
+
+func (f Food) String() string {
+	return fmt.Sprintf("%s: <%.0f, %.0f>", f.name, f.value, f.calories)
+}
+
+// names, vallues, calories list the same length.
+// name a list of strings
+// values and calories lists of numbers
+// return list of Foods
+func BuildMenu(names []string, values []float64, calories []float64) ([]Food, error) {
+	if len(names) != len(values) || len(names) != len(calories) {
+		return nil, fmt.Errorf("menu: %d names, %d values and %d calories", len(names), len(values), len(calories))
+	}
+	var menu []Food
+	for i, v := range values {
+		var f Food
+		f.Init(names[i], v, calories[i])
+		menu = append(menu, f)
+	}
+
+	return menu, nil
+}
@@ -0,0 +1,217 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	ErrEpsilon       = errors.New("menu: epsilon must be in (0, 1)")
+	ErrNegativeScale = errors.New("menu: precision must not be negative")
+)
+
+// DefaultEpsilon is the Epsilon of an FPTAS left at zero.
+const DefaultEpsilon = 0.1
+
+// FPTAS is the fully polynomial-time approximation scheme of the 0/1
+// problem: values are scaled down to whole numbers, losing at most Epsilon
+// of the optimum, and a table of the least cost of each scaled value is
+// filled in time O(n³/Epsilon). Costs and values may be any non-negative
+// numbers, but the budget must have a single dimension.
+type FPTAS struct {
+	Epsilon float64
+}
+
+// Approximation is the selection of an FPTAS: no selection is worth more
+// than Bound, which is Value/(1-Epsilon) or less.
+type Approximation struct {
+	Selection
+	Epsilon float64
+	Bound   float64
+}
+
+func (a Approximation) String() string {
+	return fmt.Sprintf("%v, epsilon=%g, bound=%.4g", a.Selection, a.Epsilon, a.Bound)
+}
+
+func (s FPTAS) Solve(menu []Food, budget Budget) (Selection, error) {
+	a, err := s.Approximate(menu, budget)
+	return a.Selection, err
+}
+
+// Approximate solves the problem and reports the bound the approximation
+// guarantees.
+func (s FPTAS) Approximate(menu []Food, budget Budget) (Approximation, error) {
+	eps := s.Epsilon
+	if eps == 0 {
+		eps = DefaultEpsilon
+	}
+	if !(eps > 0 && eps < 1) {
+		return Approximation{}, fmt.Errorf("%w: %v", ErrEpsilon, eps)
+	}
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return Approximation{}, err
+	}
+	if len(p.dims) != 1 {
+		return Approximation{}, fmt.Errorf("%w, got %v", ErrOneDimension, budget)
+	}
+
+	// Only items worth something that fit on their own can be in the
+	// optimum, and the most valuable of them is worth no more than it.
+	var useful []int
+	top := 0.0
+	for i, item := range p.items {
+		if item.Value() > 0 && p.costs[i][0] <= p.caps[0] {
+			useful = append(useful, i)
+			top = math.Max(top, item.Value())
+		}
+	}
+	taken := make([]bool, len(p.items))
+	if len(useful) == 0 {
+		return Approximation{p.selection(taken, budget), eps, 0}, nil
+	}
+
+	// Rounding each value down to a multiple of k loses less than k per
+	// item taken, so less than n·k = eps·top <= eps·optimum in all.
+	k := eps * top / float64(len(useful))
+	scaled := make([]int, len(useful))
+	total := 0
+	for j, i := range useful {
+		scaled[j] = int(p.items[i].Value() / k)
+		total += scaled[j]
+	}
+	if total+1 > maxTableCells/(len(useful)+1) {
+		return Approximation{}, fmt.Errorf("%w: %d items and %d scaled values", ErrTableTooLarge, len(useful), total+1)
+	}
+
+	// least[j][v] is the least cost of items useful[j:] worth exactly v
+	// scaled, or +Inf if none are.
+	n := len(useful)
+	least := make([][]float64, n+1)
+	least[n] = make([]float64, total+1)
+	for v := 1; v <= total; v++ {
+		least[n][v] = math.Inf(1)
+	}
+	for j := n - 1; j >= 0; j-- {
+		least[j] = make([]float64, total+1)
+		c := p.costs[useful[j]][0]
+		for v := 0; v <= total; v++ {
+			least[j][v] = least[j+1][v]
+			if v >= scaled[j] {
+				if with := least[j+1][v-scaled[j]] + c; with < least[j][v] {
+					least[j][v] = with
+				}
+			}
+		}
+	}
+	v := total
+	for least[0][v] > p.caps[0] {
+		v--
+	}
+	for j, i := range useful {
+		if least[j][v] != least[j+1][v] {
+			taken[i] = true
+			v -= scaled[j]
+		}
+	}
+	sel := p.selection(taken, budget)
+	bound := math.Min(sel.Value/(1-eps), sel.Value+eps*top)
+	return Approximation{sel, eps, bound}, nil
+}
+
+// ScaledDP is DynamicProgramming for costs with fractions: every cost is
+// rounded up to Digits decimal places, and the budget down, so that the
+// selection always fits. Rounding may leave out a selection that would
+// have fitted; Rounded reports how much that can have lost.
+type ScaledDP struct {
+	Digits int
+}
+
+// Rounded is the selection of a ScaledDP. Slack is how much more the
+// selection was charged than it costs in each dimension, and Bound the
+// value of the best selection with costs rounded down instead, which no
+// selection within the budget is worth more than.
+type Rounded struct {
+	Selection
+	Step  float64
+	Slack Budget
+	Bound float64
+}
+
+// Gap is how much more than the selection the optimum may be worth.
+func (r Rounded) Gap() float64 { return r.Bound - r.Value }
+
+func (r Rounded) String() string {
+	return fmt.Sprintf("%v, step=%g, slack=%v, bound=%.4g", r.Selection, r.Step, r.Slack, r.Bound)
+}
+
+func (s ScaledDP) Solve(menu []Food, budget Budget) (Selection, error) {
+	r, err := s.SolveRounded(menu, budget)
+	return r.Selection, err
+}
+
+// SolveRounded solves the problem and reports the error of the rounding.
+func (s ScaledDP) SolveRounded(menu []Food, budget Budget) (Rounded, error) {
+	if s.Digits < 0 {
+		return Rounded{}, fmt.Errorf("%w: %d", ErrNegativeScale, s.Digits)
+	}
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return Rounded{}, err
+	}
+	scale := math.Pow(10, float64(s.Digits))
+	up := p.scaled(scale, math.Ceil)
+	down := p.scaled(scale, math.Floor)
+	t, err := newTable(up)
+	if err != nil {
+		return Rounded{}, err
+	}
+	values := p.values()
+	taken := zeroOne(up, t, values)
+	best := zeroOneTable(down, t, values)
+
+	r := Rounded{
+		Selection: p.selection(taken, budget),
+		Step:      1 / scale,
+		Slack:     make(Budget),
+		Bound:     best[0][t.full()],
+	}
+	for d, dim := range p.dims {
+		charged := 0.0
+		for i, ok := range taken {
+			if ok {
+				charged += up.costs[i][d]
+			}
+		}
+		r.Slack[dim] = charged/scale - r.Costs[dim]
+	}
+	return r, nil
+}
+
+// scaled is p with its costs multiplied by scale and rounded by round, and
+// its caps multiplied by scale and rounded down.
+func (p *problem) scaled(scale float64, round func(float64) float64) *problem {
+	q := &problem{items: p.items, dims: p.dims}
+	for _, c := range p.caps {
+		q.caps = append(q.caps, snap(c*scale, math.Floor))
+	}
+	for _, costs := range p.costs {
+		row := make([]float64, len(costs))
+		for d, c := range costs {
+			row[d] = snap(c*scale, round)
+		}
+		q.costs = append(q.costs, row)
+	}
+	return q
+}
+
+// snap rounds x by round, unless x is a whole number but for the error of
+// the multiplication that scaled it, as 1.1*10 is.
+func snap(x float64, round func(float64) float64) float64 {
+	if r := math.Round(x); math.Abs(x-r) <= 1e-9*math.Max(1, math.Abs(x)) {
+		return r
+	}
+	return round(x)
+}
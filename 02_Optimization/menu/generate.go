@@ -0,0 +1,105 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// Family is one of the classic families of random knapsack instances of
+// Pisinger, "Core problems in knapsack algorithms" (1999). With costs drawn
+// from [1, R]:
+type Family int
+
+const (
+	Uncorrelated              Family = iota // values drawn from [1, R]
+	WeaklyCorrelated                        // values within R/10 of the cost
+	StronglyCorrelated                      // value = cost + R/10
+	InverseStronglyCorrelated               // cost = value + R/10, values drawn from [1, R]
+	SubsetSum                               // value = cost
+)
+
+var (
+	ErrNegativeSize = errors.New("menu: negative number of foods")
+	ErrBadRange     = errors.New("menu: data range must be positive")
+)
+
+var familyNames = []string{"uncorrelated", "weakly-correlated", "strongly-correlated", "inverse-strongly-correlated", "subset-sum"}
+
+func (f Family) String() string {
+	if f < 0 || int(f) >= len(familyNames) {
+		return fmt.Sprintf("Family(%d)", int(f))
+	}
+	return familyNames[f]
+}
+
+func ParseFamily(s string) (Family, error) {
+	for f, name := range familyNames {
+		if name == s {
+			return Family(f), nil
+		}
+	}
+	return 0, fmt.Errorf("menu: unknown family %q", s)
+}
+
+// Families lists every Family.
+func Families() []Family {
+	var families []Family
+	for f := range familyNames {
+		families = append(families, Family(f))
+	}
+	return families
+}
+
+// Generate returns n foods of the family with data range R, named "0" to
+// "n-1". The same seed always gives the same menu.
+func Generate(family Family, n int, R int, seed int64) ([]Food, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("%w, got %d", ErrNegativeSize, n)
+	}
+	if R < 1 {
+		return nil, fmt.Errorf("%w, got %d", ErrBadRange, R)
+	}
+	if family < 0 || int(family) >= len(familyNames) {
+		return nil, fmt.Errorf("menu: unknown family %v", family)
+	}
+	r := rand.New(rand.NewSource(seed))
+	tenth := math.Max(1, math.Floor(float64(R)/10))
+	var menu []Food
+	for i := 0; i < n; i++ {
+		var value, cost float64
+		switch family {
+		case Uncorrelated:
+			cost = float64(r.Intn(R) + 1)
+			value = float64(r.Intn(R) + 1)
+		case WeaklyCorrelated:
+			cost = float64(r.Intn(R) + 1)
+			value = math.Max(1, cost-tenth+float64(r.Intn(int(2*tenth)+1)))
+		case StronglyCorrelated:
+			cost = float64(r.Intn(R) + 1)
+			value = cost + tenth
+		case InverseStronglyCorrelated:
+			value = float64(r.Intn(R) + 1)
+			cost = value + tenth
+		case SubsetSum:
+			cost = float64(r.Intn(R) + 1)
+			value = cost
+		}
+		var f Food
+		f.Init(strconv.Itoa(i), value, cost)
+		menu = append(menu, f)
+	}
+	return menu, nil
+}
+
+// HalfCapacity is the usual capacity of a generated instance: half of the
+// total cost, so that about half of the foods fit.
+func HalfCapacity(menu []Food) Budget {
+	total := 0.0
+	for _, item := range menu {
+		total += item.Cost()
+	}
+	return CalorieBudget(math.Floor(total / 2))
+}
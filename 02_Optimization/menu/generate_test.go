@@ -0,0 +1,24 @@
+package menu
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGenerateRejectsBadArguments(t *testing.T) {
+	if _, err := Generate(Uncorrelated, -1, 100, 1); !errors.Is(err, ErrNegativeSize) {
+		t.Errorf("n = -1: got %v, want ErrNegativeSize", err)
+	}
+	for _, R := range []int{0, -5} {
+		if _, err := Generate(SubsetSum, 10, R, 1); !errors.Is(err, ErrBadRange) {
+			t.Errorf("R = %d: got %v, want ErrBadRange", R, err)
+		}
+	}
+	if _, err := Generate(Family(len(familyNames)), 10, 100, 1); err == nil {
+		t.Error("unknown family: got no error")
+	}
+	foods, err := Generate(WeaklyCorrelated, 0, 1, 1)
+	if err != nil || len(foods) != 0 {
+		t.Errorf("n = 0: got %v, %v, want no foods", foods, err)
+	}
+}
@@ -0,0 +1,123 @@
+package menu
+
+import (
+	"sort"
+)
+
+type customSort struct {
+	t    []Food
+	less func(x, y Food) bool
+}
+
+func (x customSort) Len() int           { return len(x.t) }
+func (x customSort) Less(i, j int) bool { return x.less(x.t[i], x.t[j]) }
+func (x customSort) Swap(i, j int)      { x.t[i], x.t[j] = x.t[j], x.t[i] }
+
+type CompFunction func(x, y Food) bool
+
+func ByValue(x, y Food) bool { return x.Value() > y.Value() }
+
+func ByCost(x, y Food) bool { return x.Cost() < y.Cost() }
+
+func ByDensity(x, y Food) bool { return x.Density() > y.Density() }
+
+// Ordering builds a CompFunction for a budget, so that greedy can weigh the
+// cost dimensions against each other.
+type Ordering func(budget Budget) CompFunction
+
+// Fixed is the Ordering that ignores the budget.
+func Fixed(compFunc CompFunction) Ordering {
+	return func(Budget) CompFunction { return compFunc }
+}
+
+// WeightedCost is the sum of the costs of f in each dimension of weights,
+// times the weight of that dimension.
+func WeightedCost(f Food, weights Budget) float64 {
+	total := 0.0
+	for d, w := range weights {
+		c, _ := f.CostOf(d)
+		total += w * c
+	}
+	return total
+}
+
+// normalized weighs each dimension by the inverse of its budget, so a food
+// using a tenth of the calories and a tenth of the money costs 0.2.
+func normalized(budget Budget) Budget {
+	weights := make(Budget)
+	for d, b := range budget {
+		if b > 0 {
+			weights[d] = 1 / b
+		}
+	}
+	return weights
+}
+
+// WeightedDensity orders foods by value per weighted cost.
+func WeightedDensity(weights Budget) CompFunction {
+	return func(x, y Food) bool {
+		return density(x.Value(), WeightedCost(x, weights)) > density(y.Value(), WeightedCost(y, weights))
+	}
+}
+
+// NormalizedCost orders foods by increasing share of the budget they use,
+// summed over the dimensions. For a calorie budget it is ByCost.
+func NormalizedCost(budget Budget) CompFunction {
+	weights := normalized(budget)
+	return func(x, y Food) bool {
+		return WeightedCost(x, weights) < WeightedCost(y, weights)
+	}
+}
+
+// NormalizedDensity orders foods by value per share of the budget. For a
+// calorie budget it is ByDensity.
+func NormalizedDensity(budget Budget) CompFunction {
+	return WeightedDensity(normalized(budget))
+}
+
+// Greedy takes items in compFunc order while they fit in every dimension
+// of budget.
+func Greedy(items []Food, budget Budget, compFunc CompFunction) ([]Food, float64) {
+	itemsCopy := make([]Food, len(items))
+	copy(itemsCopy, items)
+
+	sort.Stable(customSort{itemsCopy, compFunc})
+
+	var result []Food
+	totalValue := 0.0
+	totalCost := make(Budget)
+
+	for _, item := range itemsCopy {
+		fits := true
+		for d, b := range budget {
+			c, _ := item.CostOf(d)
+			if totalCost[d]+c > b {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			result = append(result, item)
+			for d := range budget {
+				c, _ := item.CostOf(d)
+				totalCost[d] += c
+			}
+			totalValue += item.Value()
+		}
+	}
+
+	return result, totalValue
+}
+
+// GreedySolver takes items in the order built by Order while they fit.
+type GreedySolver struct {
+	Order Ordering
+}
+
+func (g GreedySolver) Solve(menu []Food, budget Budget) (Selection, error) {
+	if _, err := newProblem(menu, budget); err != nil {
+		return Selection{}, err
+	}
+	taken, _ := Greedy(menu, budget, g.Order(budget))
+	return NewSelection(taken, budget), nil
+}
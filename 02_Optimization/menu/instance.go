@@ -0,0 +1,197 @@
+package menu
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Instance is a knapsack problem read from a file of standard instances.
+// Optimum is the known optimal value, or 0 if the file does not give it.
+type Instance struct {
+	Name    string
+	Menu    []Food
+	Budget  Budget
+	Optimum float64
+}
+
+// LoadInstances reads a file of standard instances: Pisinger's .csv or the
+// OR-Library's .txt.
+func LoadInstances(path string) ([]Instance, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ReadPisinger(f)
+	case ".txt":
+		return ReadORLibrary(f)
+	default:
+		return nil, fmt.Errorf("menu: %s: unknown instance format, want .csv or .txt", path)
+	}
+}
+
+// ReadPisinger reads the instance files of Pisinger's generator, as in
+// knapPI_1_50_1000.csv: for each instance a name line, then "n", "c", "z"
+// and "time" lines, one "index,value,cost,x" line per item, and a line of
+// dashes.
+func ReadPisinger(r io.Reader) ([]Instance, error) {
+	var instances []Instance
+	var inst *Instance
+	n := 0
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(text)
+		switch {
+		case text == "":
+			continue
+		case strings.HasPrefix(text, "-----"):
+			if inst != nil && len(inst.Menu) != n {
+				return nil, fmt.Errorf("menu: %s: %d items, header says %d", inst.Name, len(inst.Menu), n)
+			}
+			inst = nil
+		case inst == nil:
+			instances = append(instances, Instance{Name: text, Budget: CalorieBudget(0)})
+			inst = &instances[len(instances)-1]
+		case len(fields) == 2 && (fields[0] == "n" || fields[0] == "c" || fields[0] == "z" || fields[0] == "time"):
+			v, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("menu: line %d: %v", line, err)
+			}
+			switch fields[0] {
+			case "n":
+				n = int(v)
+			case "c":
+				inst.Budget[Calories] = v
+			case "z":
+				inst.Optimum = v
+			}
+		default:
+			parts := strings.Split(text, ",")
+			if len(parts) < 3 {
+				return nil, fmt.Errorf("menu: line %d: want index,value,cost,x, got %q", line, text)
+			}
+			value, err1 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+			cost, err2 := strconv.ParseFloat(strings.TrimSpace(parts[2]), 64)
+			if err := errors.Join(err1, err2); err != nil {
+				return nil, fmt.Errorf("menu: line %d: %v", line, err)
+			}
+			var f Food
+			f.Init(strings.TrimSpace(parts[0]), value, cost)
+			inst.Menu = append(inst.Menu, f)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if inst != nil && len(inst.Menu) != n {
+		return nil, fmt.Errorf("menu: %s: %d items, header says %d", inst.Name, len(inst.Menu), n)
+	}
+	return instances, nil
+}
+
+// ReadORLibrary reads the multi-dimensional instances of Beasley's
+// OR-Library, as in mknap1.txt: the number of instances, then for each "n
+// m optimum", the n values, the m by n costs row by row, and the m limits.
+// The first dimension is read as calories and dimension k as "w<k>".
+func ReadORLibrary(r io.Reader) ([]Instance, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+	count := 0
+	next := func() (float64, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return 0, err
+			}
+			return 0, io.ErrUnexpectedEOF
+		}
+		count++
+		v, err := strconv.ParseFloat(scanner.Text(), 64)
+		if err != nil {
+			return 0, fmt.Errorf("number %d: %v", count, err)
+		}
+		return v, nil
+	}
+	nextN := func(k int) ([]float64, error) {
+		vs := make([]float64, k)
+		for i := range vs {
+			v, err := next()
+			if err != nil {
+				return nil, err
+			}
+			vs[i] = v
+		}
+		return vs, nil
+	}
+
+	header, err := nextN(1)
+	if err != nil {
+		return nil, fmt.Errorf("menu: OR-Library: %w", err)
+	}
+	var instances []Instance
+	for k := 0; k < int(header[0]); k++ {
+		inst, err := readORInstance(nextN)
+		if err != nil {
+			return nil, fmt.Errorf("menu: OR-Library instance %d: %w", k+1, err)
+		}
+		inst.Name = fmt.Sprintf("instance %d", k+1)
+		instances = append(instances, inst)
+	}
+	return instances, nil
+}
+
+func orDim(d int) string {
+	if d == 0 {
+		return Calories
+	}
+	return "w" + strconv.Itoa(d+1)
+}
+
+func readORInstance(nextN func(int) ([]float64, error)) (Instance, error) {
+	var inst Instance
+	nmo, err := nextN(3)
+	if err != nil {
+		return inst, err
+	}
+	n, m := int(nmo[0]), int(nmo[1])
+	if n < 0 || m < 1 {
+		return inst, fmt.Errorf("bad size n=%d m=%d", n, m)
+	}
+	inst.Optimum = nmo[2]
+	values, err := nextN(n)
+	if err != nil {
+		return inst, err
+	}
+	costs := make([][]float64, m)
+	for d := range costs {
+		if costs[d], err = nextN(n); err != nil {
+			return inst, err
+		}
+	}
+	limits, err := nextN(m)
+	if err != nil {
+		return inst, err
+	}
+	inst.Budget = make(Budget)
+	for d, b := range limits {
+		inst.Budget[orDim(d)] = b
+	}
+	for i := 0; i < n; i++ {
+		var f Food
+		f.Init(strconv.Itoa(i+1), values[i], costs[0][i])
+		for d := 1; d < m; d++ {
+			f.SetAttr(orDim(d), costs[d][i])
+		}
+		inst.Menu = append(inst.Menu, f)
+	}
+	return inst, nil
+}
@@ -0,0 +1,286 @@
+package menu
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RowError reports a problem with one row of a menu file. Rows count from 1
+// and do not include the CSV header.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e *RowError) Error() string { return fmt.Sprintf("row %d: %v", e.Row, e.Err) }
+
+func (e *RowError) Unwrap() error { return e.Err }
+
+var (
+	ErrMissingField  = errors.New("missing field")
+	ErrDuplicateName = errors.New("duplicate name")
+	ErrFieldCount    = errors.New("wrong number of fields")
+)
+
+// costKeys are the accepted names of the cost column, in order of preference.
+var costKeys = []string{"calories", "cost"}
+
+// Load reads a menu from a .csv or .json file. Its errors name the file.
+func Load(path string) ([]Food, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var menu []Food
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		menu, err = LoadCSV(f)
+	case ".json":
+		menu, err = LoadJSON(f)
+	default:
+		return nil, fmt.Errorf("menu: %s: unknown menu format, want .csv or .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("menu: %s: %w", path, err)
+	}
+	return menu, nil
+}
+
+// LoadCSV reads a menu from CSV with a header row. The header must name the
+// columns name, value and calories (or cost); any other column is read as a
+// number into the food's attributes. All bad rows are reported together.
+func LoadCSV(r io.Reader) ([]Food, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("menu: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("menu: empty CSV, want a header row")
+	}
+
+	header := make([]string, len(records[0]))
+	for i, h := range records[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(h))
+	}
+
+	var rows []map[string]string
+	var errs []error
+	for i, record := range records[1:] {
+		if len(record) != len(header) {
+			errs = append(errs, &RowError{i + 1, fmt.Errorf("%w: got %d, header has %d", ErrFieldCount, len(record), len(header))})
+			rows = append(rows, nil)
+			continue
+		}
+		row := make(map[string]string)
+		for j, field := range record {
+			if field = strings.TrimSpace(field); field != "" {
+				row[header[j]] = field
+			}
+		}
+		rows = append(rows, row)
+	}
+	menu, err := buildRows(rows)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return menu, nil
+}
+
+// LoadJSON reads a menu from a JSON array of objects with the same keys as
+// the CSV columns, e.g. [{"name": "wine", "value": 89, "calories": 123}].
+func LoadJSON(r io.Reader) ([]Food, error) {
+	var objects []map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, fmt.Errorf("menu: %w", err)
+	}
+
+	var rows []map[string]string
+	for _, object := range objects {
+		row := make(map[string]string)
+		for k, raw := range object {
+			var s string
+			if err := json.Unmarshal(raw, &s); err != nil {
+				s = string(raw)
+			}
+			if s != "" && s != "null" {
+				row[strings.ToLower(k)] = s
+			}
+		}
+		rows = append(rows, row)
+	}
+	return buildRows(rows)
+}
+
+// buildRows turns parsed rows into Foods. A nil row has already been
+// reported by the caller and is skipped.
+func buildRows(rows []map[string]string) ([]Food, error) {
+	var menu []Food
+	var errs []error
+	seen := make(map[string]int)
+	for i, row := range rows {
+		if row == nil {
+			continue
+		}
+		f, err := buildRow(row)
+		if err == nil {
+			if first, ok := seen[f.Name()]; ok {
+				err = fmt.Errorf("%w %q, first seen in row %d", ErrDuplicateName, f.Name(), first)
+			} else {
+				seen[f.Name()] = i + 1
+			}
+		}
+		if err != nil {
+			errs = append(errs, &RowError{i + 1, err})
+			continue
+		}
+		menu = append(menu, f)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return menu, nil
+}
+
+func buildRow(row map[string]string) (Food, error) {
+	var f Food
+	name, ok := row["name"]
+	if !ok {
+		return f, fmt.Errorf("%w name", ErrMissingField)
+	}
+	value, err := parseField(row, "value")
+	if err != nil {
+		return f, err
+	}
+	costKey := ""
+	for _, k := range costKeys {
+		if _, ok := row[k]; ok {
+			costKey = k
+			break
+		}
+	}
+	if costKey == "" {
+		return f, fmt.Errorf("%w %s", ErrMissingField, strings.Join(costKeys, " or "))
+	}
+	cost, err := parseField(row, costKey)
+	if err != nil {
+		return f, err
+	}
+	if cost < 0 {
+		return f, fmt.Errorf("%w %v for %s", ErrNegativeCost, cost, name)
+	}
+	f.Init(name, value, cost)
+
+	for k := range row {
+		if k == "name" || k == "value" || k == costKey {
+			continue
+		}
+		v, err := parseField(row, k)
+		if err != nil {
+			return f, err
+		}
+		if v < 0 {
+			// Any column may be a cost dimension of a budget.
+			return f, fmt.Errorf("%w %s=%v for %s", ErrNegativeCost, k, v, name)
+		}
+		f.SetAttr(k, v)
+	}
+	return f, nil
+}
+
+func parseField(row map[string]string, key string) (float64, error) {
+	s, ok := row[key]
+	if !ok {
+		return 0, fmt.Errorf("%w %s", ErrMissingField, key)
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("%s: %q is not a number", key, s)
+	}
+	return v, nil
+}
+
+// Save writes a menu to a .csv file that Load reads back.
+func Save(path string, menu []Food) error {
+	if strings.ToLower(filepath.Ext(path)) != ".csv" {
+		return fmt.Errorf("menu: %s: can only save .csv", path)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteCSV(f, menu); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// SaveBudget writes a budget to a file that LoadBudget reads back, so that
+// a saved menu can be solved again within the budget it was saved with.
+func SaveBudget(path string, budget Budget) error {
+	return os.WriteFile(path, []byte(budget.String()+"\n"), 0644)
+}
+
+// LoadBudget reads a budget written as ParseBudget reads it from a file.
+func LoadBudget(path string) (Budget, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBudget(strings.TrimSpace(string(b)))
+}
+
+// WriteCSV writes a menu as CSV with the columns name, value and calories,
+// then every attribute of any food in sorted order. A food without one of
+// the attributes gets an empty field.
+func WriteCSV(w io.Writer, menu []Food) error {
+	keys := make(map[string]bool)
+	for _, item := range menu {
+		for _, k := range item.AttrKeys() {
+			keys[k] = true
+		}
+	}
+	delete(keys, Calories)
+	var extra []string
+	for k := range keys {
+		extra = append(extra, k)
+	}
+	sort.Strings(extra)
+
+	cw := csv.NewWriter(w)
+	cw.Write(append([]string{"name", "value", Calories}, extra...))
+	for _, item := range menu {
+		record := []string{item.Name(), formatField(item.Value()), formatField(item.Cost())}
+		for _, k := range extra {
+			field := ""
+			if v, ok := item.Attr(k); ok {
+				field = formatField(v)
+			}
+			record = append(record, field)
+		}
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatField(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
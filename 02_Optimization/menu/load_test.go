@@ -0,0 +1,33 @@
+package menu
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadRejectsNegativeCosts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "menu.csv")
+	data := "name,value,calories,price\n" +
+		"wine,89,123,4\n" +
+		"beer,90,154,-2\n" +
+		"pizza,95,-258,6\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Load(path)
+	if !errors.Is(err, ErrNegativeCost) {
+		t.Fatalf("got %v, want ErrNegativeCost", err)
+	}
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || rowErr.Row != 2 {
+		t.Errorf("got %v, want the error of row 2 first", err)
+	}
+	for _, want := range []string{path, "row 2", "price=-2 for beer", "row 3", "-258 for pizza"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
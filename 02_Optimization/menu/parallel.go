@@ -0,0 +1,145 @@
+package menu
+
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// incumbent is the best value found so far, shared by the workers of
+// ParallelBranchBound.
+type incumbent struct {
+	bits atomic.Uint64 // math.Float64bits of the value
+}
+
+func (inc *incumbent) value() float64 {
+	return math.Float64frombits(inc.bits.Load())
+}
+
+// offer raises the incumbent to val if that is better.
+func (inc *incumbent) offer(val float64) {
+	for {
+		old := inc.bits.Load()
+		if val <= math.Float64frombits(old) || inc.bits.CompareAndSwap(old, math.Float64bits(val)) {
+			return
+		}
+	}
+}
+
+// pruneSlack keeps branches whose bound falls short of a target by no more
+// than rounding, so that a selection worth the target is still found.
+func pruneSlack(val float64) float64 {
+	return 1e-9 * math.Max(1, math.Abs(val))
+}
+
+// ParallelBranchBound is BranchBound with the top of the decision tree cut
+// into subtrees that a pool of Workers search at once, pruning against the
+// best value any of them has found. Of the selections worth that value it
+// returns the one SearchTree returns.
+type ParallelBranchBound struct {
+	Workers int // defaults to GOMAXPROCS
+}
+
+// bnbTask is a subtree: the decisions for the first positions of the
+// branching order.
+type bnbTask struct {
+	avail []float64
+	val   float64
+}
+
+func (s ParallelBranchBound) Solve(menu []Food, budget Budget) (Selection, error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return Selection{}, err
+	}
+	r := newRelaxation(p, budget)
+	workers := s.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	inc := &incumbent{}
+
+	// Split deep enough to give every worker a few subtrees.
+	depth := 0
+	for 1<<depth < 4*workers && depth < len(menu) {
+		depth++
+	}
+
+	tasks := make(chan bnbTask)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range tasks {
+				branchBound(r, inc, depth, task.avail, task.val)
+			}
+		}()
+	}
+
+	var split func(pos int, avail []float64, val float64)
+	split = func(pos int, avail []float64, val float64) {
+		if pos == depth {
+			tasks <- bnbTask{avail, val}
+			return
+		}
+		if r.fits(pos, avail) {
+			split(pos+1, p.spend(r.order[pos], avail), val+r.values[pos])
+		}
+		split(pos+1, avail, val)
+	}
+	split(0, p.caps, 0)
+	close(tasks)
+	wg.Wait()
+
+	return p.selection(preferred(p, inc.value()), budget), nil
+}
+
+// branchBound searches the subtree below position pos depth first for a
+// value above the incumbent. Once the incumbent reaches the bound of a
+// subtree, ties included, the subtree is left.
+func branchBound(r *relaxation, inc *incumbent, pos int, avail []float64, val float64) {
+	inc.offer(val)
+	if pos == len(r.order) || val+r.bound(pos, avail) <= inc.value() {
+		return
+	}
+	if r.fits(pos, avail) {
+		branchBound(r, inc, pos+1, r.p.spend(r.order[pos], avail), val+r.values[pos])
+	}
+	branchBound(r, inc, pos+1, avail, val)
+}
+
+// preferred is the selection worth target that SearchTree returns. maxVal
+// takes an item only if that is strictly better, so of the selections worth
+// the most it returns the one that leaves out the earliest items: the first
+// one found searching in menu order, leaving each item out before taking it.
+// Knowing the target, every branch whose bound falls short of it is pruned.
+func preferred(p *problem, target float64) []bool {
+	r := menuRelaxation(p)
+	least := target - pruneSlack(target)
+	taken := make([]bool, len(p.items))
+
+	var search func(pos int, avail []float64, val float64) bool
+	search = func(pos int, avail []float64, val float64) bool {
+		if pos == len(r.order) {
+			return val >= least
+		}
+		if val+r.bound(pos, avail) < least {
+			return false
+		}
+		if search(pos+1, avail, val) {
+			return true
+		}
+		if r.fits(pos, avail) {
+			taken[pos] = true
+			if search(pos+1, p.spend(pos, avail), val+r.values[pos]) {
+				return true
+			}
+			taken[pos] = false
+		}
+		return false
+	}
+	search(0, p.caps, 0)
+	return taken
+}
@@ -0,0 +1,51 @@
+package menu
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestParallelBranchBoundNegativeValues(t *testing.T) {
+	menu := testMenu(t,
+		[]float64{1, 3, 1, 7, 6, -3, 13, 5},
+		[]float64{2, 1, 2, 1, 4, 2, 2, 2})
+	sel, err := ParallelBranchBound{Workers: 4}.Solve(menu, CalorieBudget(18))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sel.Value != 36 {
+		t.Errorf("value = %v, want 36: %v", sel.Value, sel)
+	}
+}
+
+// TestParallelBranchBoundMatchesSearchTree checks that parallel-bnb takes
+// the very items maxVal takes, on menus with negative values and on menus
+// where every full knapsack ties.
+func TestParallelBranchBoundMatchesSearchTree(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for k := 0; k < 2000; k++ {
+		var menu []Food
+		var budget Budget
+		if k%2 == 0 {
+			menu, budget = signedMenu(t, r, 10)
+		} else {
+			var err error
+			menu, err = Generate(SubsetSum, 10, 8, r.Int63())
+			if err != nil {
+				t.Fatal(err)
+			}
+			budget = HalfCapacity(menu)
+		}
+		want, err := SearchTree{}.Solve(menu, budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ParallelBranchBound{Workers: 1 + k%4}.Solve(menu, budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !sameItems(got.Items, want.Items) {
+			t.Fatalf("%v within %v: parallel-bnb %v, maxval %v", menu, budget, got, want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MaxQuantity is the menu column holding how many portions of a food may be
+// taken in Bounded mode.
+const MaxQuantity = "max"
+
+var (
+	ErrOneDimension = errors.New("menu: solver needs a one-dimensional budget")
+	ErrUnbounded    = errors.New("menu: free food can be taken without limit")
+)
+
+// Mode says how many of each food a selection may take.
+type Mode int
+
+const (
+	ZeroOne    Mode = iota // each food at most once
+	Fractional             // any part of each food, at most once
+	Bounded                // whole portions, up to the food's MaxQuantity
+	Unbounded              // whole portions, any number
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ZeroOne:
+		return "zero-one"
+	case Fractional:
+		return "fractional"
+	case Bounded:
+		return "bounded"
+	case Unbounded:
+		return "unbounded"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+func ParseMode(s string) (Mode, error) {
+	for m := ZeroOne; m <= Unbounded; m++ {
+		if m.String() == s {
+			return m, nil
+		}
+	}
+	return ZeroOne, fmt.Errorf("menu: unknown mode %q", s)
+}
+
+// NewModeSolver returns the exact solver for mode.
+func NewModeSolver(mode Mode) Solver {
+	switch mode {
+	case Fractional:
+		return FractionalSolver{}
+	case Bounded:
+		return BoundedSolver{}
+	case Unbounded:
+		return UnboundedSolver{}
+	default:
+		return DynamicProgramming{}
+	}
+}
+
+// MaxQuantity is how many portions of the food may be taken in Bounded
+// mode, one unless the menu says otherwise.
+func (f Food) MaxQuantity() float64 {
+	if q, ok := f.Attr(MaxQuantity); ok {
+		return q
+	}
+	return 1
+}
+
+// FractionalSolver takes foods in order of density and splits the first one
+// that does not fit, stopping before foods of value 0 or less, which is
+// optimal when foods can be divided. It needs a one-dimensional budget.
+type FractionalSolver struct{}
+
+func (FractionalSolver) Solve(menu []Food, budget Budget) (Selection, error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return Selection{}, err
+	}
+	if len(p.dims) != 1 {
+		return Selection{}, fmt.Errorf("%w, got %v", ErrOneDimension, budget)
+	}
+	order := make([]int, len(menu))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return density(menu[order[a]].Value(), p.costs[order[a]][0]) > density(menu[order[b]].Value(), p.costs[order[b]][0])
+	})
+
+	var items []Food
+	var quantities []float64
+	avail := p.caps[0]
+	for _, i := range order {
+		if menu[i].Value() <= 0 {
+			break
+		}
+		q := 1.0
+		if c := p.costs[i][0]; c > avail {
+			q = avail / c
+		}
+		if q <= 0 {
+			break
+		}
+		items = append(items, menu[i])
+		quantities = append(quantities, q)
+		avail -= q * p.costs[i][0]
+	}
+	return NewPortions(items, quantities, budget), nil
+}
+
+// BoundedSolver takes whole portions of each food, up to its MaxQuantity.
+// Each food is split into bundles of 1, 2, 4, ... portions, so that any
+// allowed quantity is a sum of bundles, and the bundles are solved as a
+// 0/1 problem by dynamic programming. Costs must be whole numbers.
+type BoundedSolver struct{}
+
+func (BoundedSolver) Solve(menu []Food, budget Budget) (Selection, error) {
+	p, err := newWholeProblem(menu, budget)
+	if err != nil {
+		return Selection{}, err
+	}
+	bundles := &problem{dims: p.dims, caps: p.caps}
+	var values []float64
+	var owner, size []int
+	for i, item := range menu {
+		left := int(math.Floor(item.MaxQuantity()))
+		for k := 1; left > 0; k *= 2 {
+			if k > left {
+				k = left
+			}
+			costs := make([]float64, len(p.dims))
+			for d, c := range p.costs[i] {
+				costs[d] = float64(k) * c
+			}
+			bundles.items = append(bundles.items, item)
+			bundles.costs = append(bundles.costs, costs)
+			values = append(values, float64(k)*item.Value())
+			owner = append(owner, i)
+			size = append(size, k)
+			left -= k
+		}
+	}
+	t, err := newTable(bundles)
+	if err != nil {
+		return Selection{}, err
+	}
+	taken := zeroOne(bundles, t, values)
+
+	counts := make([]int, len(menu))
+	for b, ok := range taken {
+		if ok {
+			counts[owner[b]] += size[b]
+		}
+	}
+	return p.portions(counts, budget), nil
+}
+
+// UnboundedSolver takes any number of whole portions of each food, by
+// dynamic programming over the remaining budget alone. Costs must be whole
+// numbers, and a food with value must cost something.
+type UnboundedSolver struct{}
+
+func (UnboundedSolver) Solve(menu []Food, budget Budget) (Selection, error) {
+	p, err := newWholeProblem(menu, budget)
+	if err != nil {
+		return Selection{}, err
+	}
+	for i, item := range menu {
+		if item.Value() > 0 && free(p.costs[i]) {
+			return Selection{}, fmt.Errorf("%w: %s", ErrUnbounded, item)
+		}
+	}
+	t, err := newTable(p)
+	if err != nil {
+		return Selection{}, err
+	}
+
+	// best[s] is the best value within state s and choice[s] the food
+	// taken last to reach it, or -1 for none.
+	best := make([]float64, t.states)
+	choice := make([]int, t.states)
+	for s := range choice {
+		choice[s] = -1
+		for i, item := range menu {
+			if item.Value() <= 0 || !t.fits(p, i, s) {
+				continue
+			}
+			if withVal := best[s-t.offset(p, i)] + item.Value(); withVal > best[s] {
+				best[s], choice[s] = withVal, i
+			}
+		}
+	}
+
+	counts := make([]int, len(menu))
+	for s := t.full(); choice[s] != -1; s -= t.offset(p, choice[s]) {
+		counts[choice[s]]++
+	}
+	return p.portions(counts, budget), nil
+}
+
+// free reports whether all costs are zero.
+func free(costs []float64) bool {
+	for _, c := range costs {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// portions collects counts[i] of each item of p that is taken at all.
+func (p *problem) portions(counts []int, budget Budget) Selection {
+	var items []Food
+	var quantities []float64
+	for i, n := range counts {
+		if n > 0 {
+			items = append(items, p.items[i])
+			quantities = append(quantities, float64(n))
+		}
+	}
+	return NewPortions(items, quantities, budget)
+}
@@ -0,0 +1,25 @@
+package menu
+
+import "testing"
+
+func TestFractionalSkipsWorthlessFoods(t *testing.T) {
+	tests := []struct {
+		values, costs []float64
+		budget        float64
+		want          float64
+	}{
+		{[]float64{10, 8, -4}, []float64{5, 5, 1}, 11, 18},
+		{[]float64{10, -4}, []float64{5, 5}, 10, 10},
+		{[]float64{0, 6, -1}, []float64{0, 3, 0}, 2, 4},
+	}
+	for _, tt := range tests {
+		menu := testMenu(t, tt.values, tt.costs)
+		sel, err := FractionalSolver{}.Solve(menu, CalorieBudget(tt.budget))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sel.Value != tt.want {
+			t.Errorf("%v within %v: value %v, want %v", menu, tt.budget, sel.Value, tt.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package menu
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// GreedyStrategy is a greedy ordering with a name to report it by.
+type GreedyStrategy struct {
+	Name  string
+	Order Ordering
+}
+
+// GreedyStrategies are the orderings of the lectures: by value, by cost and
+// by density, with cost normalized over the budget dimensions.
+var GreedyStrategies = []GreedyStrategy{
+	{"value", Fixed(ByValue)},
+	{"cost", NormalizedCost},
+	{"density", NormalizedDensity},
+}
+
+// StrategyGap is how far one greedy strategy falls short of the optimum.
+type StrategyGap struct {
+	Name      string
+	Selection Selection
+	Gap       float64 // optimum value less greedy value
+	RelGap    float64 // Gap as a fraction of the optimum value
+	Missing   []Food  // in the optimum but not taken by greedy
+	Extra     []Food  // taken by greedy but not in the optimum
+}
+
+// GapReport compares every greedy strategy with the exact optimum for one
+// budget. Bound is the LP relaxation upper bound: the fractional optimum,
+// or with several dimensions the smallest fractional optimum of any one.
+type GapReport struct {
+	Budget     Budget
+	Optimum    Selection
+	Bound      float64
+	Strategies []StrategyGap
+}
+
+// GreedyReport solves menu within budget with each of GreedyStrategies and
+// exactly with MemoSearch.
+func GreedyReport(menu []Food, budget Budget) (GapReport, error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return GapReport{}, err
+	}
+	opt, err := MemoSearch{}.Solve(menu, budget)
+	if err != nil {
+		return GapReport{}, err
+	}
+	r := GapReport{
+		Budget:  budget,
+		Optimum: opt,
+		Bound:   newRelaxation(p, budget).bound(0, p.caps),
+	}
+	for _, strategy := range GreedyStrategies {
+		sel, err := GreedySolver{strategy.Order}.Solve(menu, budget)
+		if err != nil {
+			return GapReport{}, err
+		}
+		gap := StrategyGap{Name: strategy.Name, Selection: sel, Gap: opt.Value - sel.Value}
+		if opt.Value > 0 {
+			gap.RelGap = gap.Gap / opt.Value
+		}
+		gap.Missing, gap.Extra = diffItems(opt.Items, sel.Items)
+		r.Strategies = append(r.Strategies, gap)
+	}
+	return r, nil
+}
+
+// GreedySweep is GreedyReport for each of budgets.
+func GreedySweep(menu []Food, budgets []Budget) ([]GapReport, error) {
+	var reports []GapReport
+	for _, budget := range budgets {
+		r, err := GreedyReport(menu, budget)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+// diffItems returns the items of a not in b and of b not in a, by name.
+func diffItems(a, b []Food) ([]Food, []Food) {
+	inA := make(map[string]bool)
+	inB := make(map[string]bool)
+	for _, item := range a {
+		inA[item.Name()] = true
+	}
+	for _, item := range b {
+		inB[item.Name()] = true
+	}
+	var onlyA, onlyB []Food
+	for _, item := range a {
+		if !inB[item.Name()] {
+			onlyA = append(onlyA, item)
+		}
+	}
+	for _, item := range b {
+		if !inA[item.Name()] {
+			onlyB = append(onlyB, item)
+		}
+	}
+	return onlyA, onlyB
+}
+
+func itemNames(items []Food) string {
+	if len(items) == 0 {
+		return "-"
+	}
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+// Write prints the report as a table.
+func (r GapReport) Write(w io.Writer) error {
+	fmt.Fprintf(w, "budget %v: optimum %.4g, LP bound %.4g\n", r.Budget, r.Optimum.Value, r.Bound)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  greedy by\tvalue\tgap\trel. gap\tmissing\textra")
+	for _, s := range r.Strategies {
+		fmt.Fprintf(tw, "  %s\t%.4g\t%.4g\t%.1f%%\t%s\t%s\n",
+			s.Name, s.Selection.Value, s.Gap, 100*s.RelGap, itemNames(s.Missing), itemNames(s.Extra))
+	}
+	return tw.Flush()
+}
+
+// WriteSweepSummary prints, for each strategy, the budget at which it falls
+// furthest below the optimum and how often it is optimal.
+func WriteSweepSummary(w io.Writer, reports []GapReport) {
+	for k, strategy := range GreedyStrategies {
+		worst := -1
+		numOptimal := 0
+		for i, r := range reports {
+			s := r.Strategies[k]
+			if s.Gap <= 0 {
+				numOptimal++
+			}
+			if worst < 0 || s.RelGap > reports[worst].Strategies[k].RelGap {
+				worst = i
+			}
+		}
+		if worst < 0 {
+			continue
+		}
+		s := reports[worst].Strategies[k]
+		fmt.Fprintf(w, "greedy by %s: optimal for %d of %d budgets, worst %.1f%% below optimum at %v\n",
+			strategy.Name, numOptimal, len(reports), 100*s.RelGap, reports[worst].Budget)
+	}
+}
@@ -0,0 +1,38 @@
+package menu
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGreedyReportBound(t *testing.T) {
+	menu := testMenu(t, []float64{10, 8, -4}, []float64{5, 5, 1})
+	report, err := GreedyReport(menu, CalorieBudget(11))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Optimum.Value != 18 || report.Bound != 18 {
+		t.Errorf("optimum %v and bound %v, want 18 and 18", report.Optimum.Value, report.Bound)
+	}
+}
+
+// TestGreedyReportBoundIsFractional checks that with one dimension the
+// bound is the fractional optimum, which is never below the optimum.
+func TestGreedyReportBoundIsFractional(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for k := 0; k < 2000; k++ {
+		menu, budget := signedMenu(t, r, 8)
+		report, err := GreedyReport(menu, budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		frac, err := FractionalSolver{}.Solve(menu, budget)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(report.Bound-frac.Value) > 1e-9 || report.Bound < report.Optimum.Value {
+			t.Fatalf("%v within %v: bound %v, fractional %v, optimum %v", menu, budget, report.Bound, frac.Value, report.Optimum.Value)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package menu
+
+import (
+	"encoding/binary"
+	"math"
+
+	"../memo"
+)
+
+// node names a call of the search for a Tracer: the node it was called
+// from, the branch taken there and the value taken so far.
+type node struct {
+	parent int
+	branch string
+	value  float64
+}
+
+func maxVal(p *problem, i int, avail []float64, tr *Tracer, at node) (float64, []Food) {
+	id := tr.enter(at.parent, at.branch, i, avail, at.value)
+	var taken []Food
+	var val float64
+	pruned := false
+	if i == len(p.items) {
+		val, taken = 0, nil
+	} else if !p.fits(i, avail) {
+		// Explore right branch only
+		pruned = true
+		val, taken = maxVal(p, i+1, avail, tr, node{id, "skip", at.value})
+	} else {
+		nextItem := p.items[i]
+		// Explore left branch
+		withVal, withToTake := maxVal(p, i+1, p.spend(i, avail), tr, node{id, "take", at.value + nextItem.Value()})
+		withVal += nextItem.Value()
+		// Explore right branch
+		withoutVal, withoutToTake := maxVal(p, i+1, avail, tr, node{id, "skip", at.value})
+		// Choose better branch
+		if withVal > withoutVal {
+			val, taken = withVal, append(withToTake, nextItem)
+		} else {
+			val, taken = withoutVal, withoutToTake
+		}
+	}
+	tr.leave(id, val, false, pruned)
+	return val, taken
+}
+
+// SearchTree explores the whole take / don't take decision tree. If Trace
+// is set, every node explored is recorded in it.
+type SearchTree struct {
+	Trace *Tracer
+}
+
+func (s SearchTree) Solve(menu []Food, budget Budget) (Selection, error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return Selection{}, err
+	}
+	s.Trace.start(p)
+	_, taken := maxVal(p, 0, p.caps, s.Trace, node{parent: -1})
+	// maxVal appends on the way back up; list the items in menu order.
+	for i, j := 0, len(taken)-1; i < j; i, j = i+1, j-1 {
+		taken[i], taken[j] = taken[j], taken[i]
+	}
+	return NewSelection(taken, budget), nil
+}
+
+// searchMemo remembers the best value of items i.. within avail.
+type searchMemo interface {
+	lookup(i int, avail []float64) (float64, bool)
+	store(i int, avail []float64, val float64)
+}
+
+// denseMemo is a searchMemo laid out like the tables of
+// DynamicProgramming, for whole-number costs. Unknown entries are NaN.
+type denseMemo struct {
+	t    *table
+	vals []float64
+}
+
+func newDenseMemo(p *problem, t *table) *denseMemo {
+	m := &denseMemo{t, make([]float64, (len(p.items)+1)*t.states)}
+	for k := range m.vals {
+		m.vals[k] = math.NaN()
+	}
+	return m
+}
+
+func (m *denseMemo) index(i int, avail []float64) int {
+	s := 0
+	for d, a := range avail {
+		s += int(a) * m.t.strides[d]
+	}
+	return i*m.t.states + s
+}
+
+func (m *denseMemo) lookup(i int, avail []float64) (float64, bool) {
+	v := m.vals[m.index(i, avail)]
+	return v, !math.IsNaN(v)
+}
+
+func (m *denseMemo) store(i int, avail []float64, val float64) {
+	m.vals[m.index(i, avail)] = val
+}
+
+// sparseKey packs the exact bits of avail into a string, as the map key of
+// sparseMemo.
+type sparseKey struct {
+	i     int
+	avail string
+}
+
+// sparseMemo is a searchMemo for any costs, holding only the states the
+// search reaches, or with a capacity only the most recently used of them.
+type sparseMemo struct {
+	m *memo.Memo[sparseKey, float64]
+}
+
+func newSparseMemo(capacity int) sparseMemo {
+	return sparseMemo{memo.New[sparseKey, float64](capacity)}
+}
+
+func (m sparseMemo) key(i int, avail []float64) sparseKey {
+	buf := make([]byte, 8*len(avail))
+	for d, a := range avail {
+		binary.LittleEndian.PutUint64(buf[8*d:], math.Float64bits(a))
+	}
+	return sparseKey{i, string(buf)}
+}
+
+func (m sparseMemo) lookup(i int, avail []float64) (float64, bool) {
+	return m.m.Get(m.key(i, avail))
+}
+
+func (m sparseMemo) store(i int, avail []float64, val float64) {
+	m.m.Put(m.key(i, avail), val)
+}
+
+// newSearchMemo picks a denseMemo when costs are whole numbers, the table
+// fits in maxTableCells and no capacity is asked for, and a sparseMemo
+// otherwise. It returns the avail to start the search from.
+func newSearchMemo(p *problem, capacity int) (searchMemo, []float64) {
+	if capacity == 0 && p.whole() == nil {
+		if t, err := newTable(p); err == nil {
+			avail := make([]float64, len(p.caps))
+			for d, c := range t.caps {
+				avail[d] = float64(c)
+			}
+			return newDenseMemo(p, t), avail
+		}
+	}
+	return newSparseMemo(capacity), p.caps
+}
+
+func fastMaxVal(p *problem, i int, avail []float64, memo searchMemo, tr *Tracer, at node) float64 {
+	id := tr.enter(at.parent, at.branch, i, avail, at.value)
+	if val, ok := memo.lookup(i, avail); ok {
+		tr.leave(id, val, true, false)
+		return val
+	}
+	var val float64
+	pruned := false
+	if i == len(p.items) {
+		val = 0
+	} else if !p.fits(i, avail) {
+		// Explore right branch only
+		pruned = true
+		val = fastMaxVal(p, i+1, avail, memo, tr, node{id, "skip", at.value})
+	} else {
+		value := p.items[i].Value()
+		// Explore left branch
+		withVal := fastMaxVal(p, i+1, p.spend(i, avail), memo, tr, node{id, "take", at.value + value}) + value
+		// Explore right branch
+		withoutVal := fastMaxVal(p, i+1, avail, memo, tr, node{id, "skip", at.value})
+		// Choose better branch
+		val = math.Max(withVal, withoutVal)
+	}
+	memo.store(i, avail, val)
+	tr.leave(id, val, false, pruned)
+	return val
+}
+
+// MemoSearch is SearchTree with the best value of every (item, avail)
+// sub-problem remembered. The items taken are read back by walking down the
+// remembered values rather than stored with each of them. A Capacity bounds
+// the memory used, at the price of solving forgotten sub-problems again. If
+// Trace is set, the search is recorded in it, not the reading back.
+type MemoSearch struct {
+	Capacity int
+	Trace    *Tracer
+}
+
+func (s MemoSearch) Solve(menu []Food, budget Budget) (Selection, error) {
+	p, err := newProblem(menu, budget)
+	if err != nil {
+		return Selection{}, err
+	}
+	memo, avail := newSearchMemo(p, s.Capacity)
+	s.Trace.start(p)
+	fastMaxVal(p, 0, avail, memo, s.Trace, node{parent: -1})
+	return p.selection(p.readBack(memo, avail), budget), nil
+}
+
+// readBack walks down the values of memo from avail to the items taken,
+// solving again the sub-problems memo has forgotten.
+func (p *problem) readBack(memo searchMemo, avail []float64) []bool {
+	taken := make([]bool, len(p.items))
+	for i, item := range p.items {
+		if !p.fits(i, avail) {
+			continue
+		}
+		left := p.spend(i, avail)
+		if fastMaxVal(p, i+1, left, memo, nil, node{})+item.Value() > fastMaxVal(p, i+1, avail, memo, nil, node{}) {
+			taken[i] = true
+			avail = left
+		}
+	}
+	return taken
+}
@@ -0,0 +1,246 @@
+package menu
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+)
+
+// ItemSensitivity is how firmly one item is in or out of the optimum: the
+// best values with it taken and without it, and Flip, the change of its
+// value at which it would be worth the other way. Flip is negative for an
+// item taken, +Inf for an item that never fits.
+type ItemSensitivity struct {
+	Food    Food
+	Taken   bool
+	With    float64
+	Without float64
+	Flip    float64
+}
+
+// Analysis is the optimum of a menu within a budget and how it would change
+// with the menu or the budget. Marginal[j] is the value gained by calorie
+// j+1 added to the budget. The tables it is computed from are kept for
+// WhatIf.
+type Analysis struct {
+	Budget    Budget
+	Selection Selection
+	Items     []ItemSensitivity
+	Marginal  []float64
+
+	p      *problem
+	t      *table
+	pre    [][]float64 // pre[i][s]: best value of the items before i within s
+	suf    [][]float64 // suf[i][s]: best value of items i.. within s
+	target int         // the state of Budget
+}
+
+// Analyze solves menu within budget by dynamic programming, with tables
+// built for extra more calories, and reads the sensitivity of every item
+// from a table of prefixes and one of suffixes of the menu.
+func Analyze(menu []Food, budget Budget, extra int) (*Analysis, error) {
+	if _, ok := budget[Calories]; extra > 0 && !ok {
+		return nil, fmt.Errorf("menu: marginal value needs a %s budget", Calories)
+	}
+	grown := make(Budget)
+	for d, b := range budget {
+		grown[d] = b
+	}
+	if extra > 0 {
+		grown[Calories] += float64(extra)
+	}
+	p, err := newWholeProblem(menu, grown)
+	if err != nil {
+		return nil, err
+	}
+	t, err := newTable(p)
+	if err != nil {
+		return nil, err
+	}
+	values := p.values()
+	a := &Analysis{
+		Budget: budget,
+		p:      p,
+		t:      t,
+		pre:    prefixTable(p, t, values),
+		suf:    zeroOneTable(p, t, values),
+		target: t.full(),
+	}
+	cal := -1
+	for d, dim := range p.dims {
+		if dim == Calories {
+			cal = d
+		}
+	}
+	if extra > 0 {
+		a.target -= extra * t.strides[cal]
+	}
+
+	taken := backtrack(p, t, a.suf, a.target)
+	a.Selection = p.selection(taken, budget)
+	for i := range menu {
+		with := a.combine(i, t.offset(p, i), p.costs[i]) + values[i]
+		without := a.combine(i, 0, nil)
+		a.Items = append(a.Items, ItemSensitivity{menu[i], taken[i], with, without, without - with})
+	}
+	for j := 0; j < extra; j++ {
+		s := a.target + j*t.strides[cal]
+		a.Marginal = append(a.Marginal, a.suf[0][s+t.strides[cal]]-a.suf[0][s])
+	}
+	return a, nil
+}
+
+// prefixTable fills pre[i][s], the best value of the items before i within
+// state s.
+func prefixTable(p *problem, t *table, values []float64) [][]float64 {
+	n := len(p.items)
+	pre := make([][]float64, n+1)
+	pre[0] = make([]float64, t.states)
+	for i := 0; i < n; i++ {
+		pre[i+1] = make([]float64, t.states)
+		off := t.offset(p, i)
+		for s := 0; s < t.states; s++ {
+			pre[i+1][s] = pre[i][s]
+			if t.fits(p, i, s) {
+				if withVal := pre[i][s-off] + values[i]; withVal > pre[i+1][s] {
+					pre[i+1][s] = withVal
+				}
+			}
+		}
+	}
+	return pre
+}
+
+// split is the best way to share what is left of the budget after spending
+// costs, at offset off, between the items before i and those after it: the
+// state given to the items before i and the total value.
+func (a *Analysis) split(i, off int, costs []float64) (int, float64) {
+	t := a.t
+	bestS, best := -1, math.Inf(-1)
+	for s := 0; s < t.states; s++ {
+		// The items after i get the target less s and off, if no
+		// dimension of s and costs exceeds the target.
+		ok := true
+		for d := range t.caps {
+			c := 0
+			if costs != nil {
+				c = int(costs[d])
+			}
+			if digit(t, s, d)+c > digit(t, a.target, d) {
+				ok = false
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		if v := a.pre[i][s] + a.suf[i+1][a.target-s-off]; v > best {
+			bestS, best = s, v
+		}
+	}
+	return bestS, best
+}
+
+func (a *Analysis) combine(i, off int, costs []float64) float64 {
+	_, best := a.split(i, off, costs)
+	return best
+}
+
+// digit is the remaining capacity of dimension d in state s.
+func digit(t *table, s, d int) int {
+	return (s / t.strides[d]) % (t.caps[d] + 1)
+}
+
+// backtrackPrefix reads the items before i taken for state s out of a
+// prefixTable.
+func backtrackPrefix(p *problem, t *table, pre [][]float64, i, s int, taken []bool) {
+	for j := i - 1; j >= 0; j-- {
+		if pre[j+1][s] != pre[j][s] {
+			taken[j] = true
+			s -= t.offset(p, j)
+		}
+	}
+}
+
+// WhatIf returns the best selection if item i of the menu were f instead,
+// read from the tables of the analysis rather than solved again. The costs
+// of f must be whole numbers.
+func (a *Analysis) WhatIf(i int, f Food) (Selection, error) {
+	p, t := a.p, a.t
+	if i < 0 || i >= len(p.items) {
+		return Selection{}, fmt.Errorf("menu: no item %d in a menu of %d", i, len(p.items))
+	}
+	costs := make([]float64, len(p.dims))
+	off := 0
+	fits := true
+	for d, dim := range p.dims {
+		c, ok := f.CostOf(dim)
+		if !ok {
+			return Selection{}, fmt.Errorf("%w %s: %s", ErrMissingCost, dim, f)
+		}
+		if c < 0 {
+			return Selection{}, fmt.Errorf("%w: %s=%v for %s", ErrNegativeCost, dim, c, f)
+		}
+		if c != math.Trunc(c) {
+			return Selection{}, fmt.Errorf("%w: %s=%v for %s", ErrFractionalCost, dim, c, f)
+		}
+		if int(c) > digit(t, a.target, d) {
+			fits = false
+		}
+		costs[d] = c
+		off += int(c) * t.strides[d]
+	}
+
+	take := false
+	s, best := a.split(i, 0, nil)
+	if fits {
+		if sw, with := a.split(i, off, costs); with+f.Value() > best {
+			take, s = true, sw
+		}
+	}
+	rest := a.target - s
+	if take {
+		rest -= off
+	}
+	taken := make([]bool, len(p.items))
+	backtrackPrefix(p, t, a.pre, i, s, taken)
+	for j := i + 1; j < len(p.items); j++ {
+		if a.suf[j][rest] != a.suf[j+1][rest] {
+			taken[j] = true
+			rest -= t.offset(p, j)
+		}
+	}
+	var items []Food
+	for j, ok := range taken {
+		if ok {
+			items = append(items, p.items[j])
+		} else if j == i && take {
+			items = append(items, f)
+		}
+	}
+	return NewSelection(items, a.Budget), nil
+}
+
+// Write prints the analysis as a table of the items, then the marginal
+// values.
+func (a *Analysis) Write(w io.Writer) error {
+	fmt.Fprintf(w, "budget %v: optimum %.4g\n", a.Budget, a.Selection.Value)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  item\ttaken\twith\twithout\tflip at")
+	for _, it := range a.Items {
+		flip := fmt.Sprintf("%+.4g", it.Flip)
+		if math.IsInf(it.Flip, 1) {
+			flip = "never fits"
+		}
+		fmt.Fprintf(tw, "  %s\t%v\t%.4g\t%.4g\t%s\n", it.Food.Name(), it.Taken, it.With, it.Without, flip)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if len(a.Marginal) > 0 {
+		_, err := fmt.Fprintf(w, "value of each extra calorie: %v\n", a.Marginal)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNegativeBudget = errors.New("menu: negative budget")
+	ErrNegativeCost   = errors.New("menu: negative cost")
+	ErrFractionalCost = errors.New("menu: cost is not a whole number")
+)
+
+// Selection is the answer of a Solver: the foods taken and their totals.
+// Cost is the total calories and Costs the total in each budget dimension.
+// Quantities, when not nil, holds how much of each item is taken; a nil
+// Quantities means one of each.
+type Selection struct {
+	Items      []Food
+	Quantities []float64
+	Value      float64
+	Cost       float64
+	Costs      Budget
+}
+
+func NewSelection(items []Food, budget Budget) Selection {
+	s := Selection{Items: items, Costs: make(Budget)}
+	for d := range budget {
+		s.Costs[d] = 0
+	}
+	for _, item := range items {
+		s.Value += item.Value()
+		s.Cost += item.Cost()
+		for d := range budget {
+			c, _ := item.CostOf(d)
+			s.Costs[d] += c
+		}
+	}
+	return s
+}
+
+// NewPortions is NewSelection for quantities[i] of each items[i].
+func NewPortions(items []Food, quantities []float64, budget Budget) Selection {
+	s := Selection{Items: items, Quantities: quantities, Costs: make(Budget)}
+	for d := range budget {
+		s.Costs[d] = 0
+	}
+	for i, item := range items {
+		q := quantities[i]
+		s.Value += q * item.Value()
+		s.Cost += q * item.Cost()
+		for d := range budget {
+			c, _ := item.CostOf(d)
+			s.Costs[d] += q * c
+		}
+	}
+	return s
+}
+
+// Quantity is how much of Items[i] is taken.
+func (s Selection) Quantity(i int) float64 {
+	if s.Quantities == nil {
+		return 1
+	}
+	return s.Quantities[i]
+}
+
+func (s Selection) String() string {
+	if s.Quantities == nil {
+		return fmt.Sprintf("value=%.0f, %v, items=%v", s.Value, s.Costs, s.Items)
+	}
+	var portions []string
+	for i, item := range s.Items {
+		portions = append(portions, fmt.Sprintf("%g x %s", s.Quantity(i), item))
+	}
+	return fmt.Sprintf("value=%.0f, %v, items=[%s]", s.Value, s.Costs, strings.Join(portions, " "))
+}
+
+// Solver chooses foods from menu whose total cost is within budget in every
+// dimension.
+type Solver interface {
+	Solve(menu []Food, budget Budget) (Selection, error)
+}
+
+var solvers = map[string]func() Solver{
+	"greedy-value":   func() Solver { return GreedySolver{Fixed(ByValue)} },
+	"greedy-cost":    func() Solver { return GreedySolver{NormalizedCost} },
+	"greedy-density": func() Solver { return GreedySolver{NormalizedDensity} },
+	"maxval":         func() Solver { return SearchTree{} },
+	"fastmaxval":     func() Solver { return MemoSearch{} },
+	"dp":             func() Solver { return DynamicProgramming{} },
+	"bnb":            func() Solver { return BranchBound{} },
+	"parallel-bnb":   func() Solver { return ParallelBranchBound{} },
+	"fractional":     func() Solver { return FractionalSolver{} },
+	"bounded":        func() Solver { return BoundedSolver{} },
+	"unbounded":      func() Solver { return UnboundedSolver{} },
+	"fptas":          func() Solver { return FPTAS{} },
+	"scaled-dp":      func() Solver { return ScaledDP{Digits: 2} },
+}
+
+// NewSolver returns the solver registered under name.
+func NewSolver(name string) (Solver, error) {
+	newSolver, ok := solvers[name]
+	if !ok {
+		return nil, fmt.Errorf("menu: unknown solver %q (have %s)", name, strings.Join(SolverNames(), ", "))
+	}
+	return newSolver(), nil
+}
+
+// SolverNames lists the registered solver names in sorted order.
+func SolverNames() []string {
+	var names []string
+	for name := range solvers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// selection collects the items of p marked in taken.
+func (p *problem) selection(taken []bool, budget Budget) Selection {
+	var items []Food
+	for i, ok := range taken {
+		if ok {
+			items = append(items, p.items[i])
+		}
+	}
+	return NewSelection(items, budget)
+}
@@ -0,0 +1,97 @@
+package menu
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SweepPoint is the optimal selection for one calorie budget.
+type SweepPoint struct {
+	Budget    float64
+	Selection Selection
+}
+
+// Sweep holds the optimum for every whole calorie budget from 0 up.
+type Sweep struct {
+	Points []SweepPoint // Points[b] is the optimum for budget b
+}
+
+// BudgetSweep finds the optimal selection for every calorie budget from 0
+// to maxBudget. All budgets are read out of one dynamic programming table,
+// so costs must be whole numbers.
+func BudgetSweep(menu []Food, maxBudget float64) (*Sweep, error) {
+	budget := CalorieBudget(maxBudget)
+	p, err := newWholeProblem(menu, budget)
+	if err != nil {
+		return nil, err
+	}
+	t, err := newTable(p)
+	if err != nil {
+		return nil, err
+	}
+	best := zeroOneTable(p, t, p.values())
+
+	sweep := &Sweep{}
+	for b := 0; b < t.states; b++ {
+		taken := backtrack(p, t, best, b)
+		sweep.Points = append(sweep.Points, SweepPoint{float64(b), p.selection(taken, CalorieBudget(float64(b)))})
+	}
+	return sweep, nil
+}
+
+// Frontier is the Pareto frontier of (cost, value): the budgets at which
+// the optimal value rises. The optimum at such a budget spends all of it,
+// and no cheaper selection is worth as much.
+func (s *Sweep) Frontier() []SweepPoint {
+	var frontier []SweepPoint
+	for b, pt := range s.Points {
+		if b == 0 || pt.Selection.Value > s.Points[b-1].Selection.Value {
+			frontier = append(frontier, pt)
+		}
+	}
+	return frontier
+}
+
+// Breakpoints are the budgets at which the optimal item set changes. They
+// include the frontier, and also budgets where an equally good set takes
+// over.
+func (s *Sweep) Breakpoints() []SweepPoint {
+	var breakpoints []SweepPoint
+	for b, pt := range s.Points {
+		if b == 0 || !sameItems(pt.Selection.Items, s.Points[b-1].Selection.Items) {
+			breakpoints = append(breakpoints, pt)
+		}
+	}
+	return breakpoints
+}
+
+func sameItems(a, b []Food) bool {
+	onlyA, onlyB := diffItems(a, b)
+	return len(onlyA) == 0 && len(onlyB) == 0
+}
+
+// WriteCSV writes one row per breakpoint: the budget, the optimal value and
+// its cost, whether it is on the frontier, and the items separated by ";".
+func (s *Sweep) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"budget", "value", "cost", "frontier", "items"})
+	for _, pt := range s.Breakpoints() {
+		b := int(pt.Budget)
+		onFrontier := b == 0 || pt.Selection.Value > s.Points[b-1].Selection.Value
+		var names []string
+		for _, item := range pt.Selection.Items {
+			names = append(names, item.Name())
+		}
+		cw.Write([]string{
+			strconv.FormatFloat(pt.Budget, 'f', -1, 64),
+			strconv.FormatFloat(pt.Selection.Value, 'f', -1, 64),
+			strconv.FormatFloat(pt.Selection.Cost, 'f', -1, 64),
+			strconv.FormatBool(onFrontier),
+			strings.Join(names, ";"),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,129 @@
+package menu
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TraceNode is one call of the search: the decision about Item given Avail
+// left and Value taken so far. At a leaf Item is the length of the menu.
+type TraceNode struct {
+	ID     int       `json:"id"`
+	Parent int       `json:"parent"`           // -1 at the root
+	Branch string    `json:"branch,omitempty"` // "take" or "skip"
+	Item   int       `json:"item"`
+	Avail  []float64 `json:"avail"`
+	Value  float64   `json:"value"`
+	Best   float64   `json:"best"`             // best value the subtree adds
+	Memo   bool      `json:"memo,omitempty"`   // Best came from the memo
+	Pruned bool      `json:"pruned,omitempty"` // Item did not fit, so only skip was explored
+}
+
+// Tracer records the decision tree explored by SearchTree or MemoSearch.
+// After MaxNodes nodes, if set, it stops recording and sets Truncated.
+type Tracer struct {
+	MaxNodes  int         `json:"-"`
+	Dims      []string    `json:"dims"`
+	Items     []string    `json:"items"`
+	Nodes     []TraceNode `json:"nodes"`
+	Truncated bool        `json:"truncated,omitempty"`
+}
+
+func (t *Tracer) start(p *problem) {
+	if t == nil {
+		return
+	}
+	t.Dims = p.dims
+	t.Items = nil
+	for _, item := range p.items {
+		t.Items = append(t.Items, item.Name())
+	}
+	t.Nodes = nil
+	t.Truncated = false
+}
+
+// enter records a node and returns its ID, or -1 if nothing is recorded.
+func (t *Tracer) enter(parent int, branch string, item int, avail []float64, value float64) int {
+	if t == nil {
+		return -1
+	}
+	if t.MaxNodes > 0 && len(t.Nodes) >= t.MaxNodes {
+		t.Truncated = true
+		return -1
+	}
+	id := len(t.Nodes)
+	t.Nodes = append(t.Nodes, TraceNode{
+		ID:     id,
+		Parent: parent,
+		Branch: branch,
+		Item:   item,
+		Avail:  append([]float64(nil), avail...),
+		Value:  value,
+	})
+	return id
+}
+
+// leave records the outcome of node id.
+func (t *Tracer) leave(id int, best float64, memo bool, pruned bool) {
+	if t == nil || id < 0 {
+		return
+	}
+	n := &t.Nodes[id]
+	n.Best, n.Memo, n.Pruned = best, memo, pruned
+}
+
+func (t *Tracer) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t)
+}
+
+// WriteDOT writes the tree for Graphviz. Nodes served from the memo are
+// filled blue and nodes whose item did not fit are grey.
+func (t *Tracer) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph search {\n")
+	b.WriteString("\tnode [shape=box, fontname=\"Helvetica\"];\n")
+	for _, n := range t.Nodes {
+		label := "leaf"
+		if n.Item < len(t.Items) {
+			label = t.Items[n.Item]
+		}
+		var avail []string
+		for d, a := range n.Avail {
+			avail = append(avail, fmt.Sprintf("%s=%g", t.Dims[d], a))
+		}
+		value := fmt.Sprintf("value=%g best=+%g", n.Value, n.Best)
+		style := ""
+		switch {
+		case n.Memo:
+			style = ", style=filled, fillcolor=lightblue"
+		case n.Pruned:
+			style = ", style=filled, fillcolor=lightgrey"
+		}
+		fmt.Fprintf(&b, "\tn%d [label=%s%s];\n",
+			n.ID, dotLabel(label, strings.Join(avail, " "), value), style)
+		if n.Parent >= 0 {
+			fmt.Fprintf(&b, "\tn%d -> n%d [label=%s];\n", n.Parent, n.ID, dotLabel(n.Branch))
+		}
+	}
+	if t.Truncated {
+		fmt.Fprintf(&b, "\ttruncated [label=\"truncated after %d nodes\", shape=plaintext];\n", len(t.Nodes))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// dotLabel is a quoted Graphviz label showing lines one under the other,
+// with the quotes and backslashes of food names escaped.
+func dotLabel(lines ...string) string {
+	for i, line := range lines {
+		lines[i] = dotEscaper.Replace(line)
+	}
+	return `"` + strings.Join(lines, `\n`) + `"`
+}
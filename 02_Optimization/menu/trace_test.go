@@ -0,0 +1,31 @@
+package menu
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteDOTEscapesNames(t *testing.T) {
+	tr := &Tracer{
+		Dims:  []string{"calories"},
+		Items: []string{`6" sub`, `a\b`},
+		Nodes: []TraceNode{
+			{ID: 0, Parent: -1, Item: 0, Avail: []float64{10}},
+			{ID: 1, Parent: 0, Branch: "take", Item: 1, Avail: []float64{4}, Value: 3},
+		},
+	}
+	var b strings.Builder
+	if err := tr.WriteDOT(&b); err != nil {
+		t.Fatal(err)
+	}
+	dot := b.String()
+	for _, want := range []string{
+		`n0 [label="6\" sub\ncalories=10\nvalue=0 best=+0"];`,
+		`n1 [label="a\\b\ncalories=4\nvalue=3 best=+0"];`,
+		`n0 -> n1 [label="take"];`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("missing %s in\n%s", want, dot)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image/color"
+	"log"
+	"os"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+
+	"../menu"
+)
+
+func plotSweep(sweep *menu.Sweep, fileName string) {
+	p, err := plot.New()
+	if err != nil {
+		log.Fatalln("plot.New()", err)
+		return
+	}
+
+	p.Title.Text = "Optimal Value by Calorie Budget"
+	p.X.Label.Text = "Calories"
+	p.Y.Label.Text = "Total Value"
+	p.Add(plotter.NewGrid())
+
+	pts := make(plotter.XYs, len(sweep.Points))
+	for i, pt := range sweep.Points {
+		pts[i].X = pt.Budget
+		pts[i].Y = pt.Selection.Value
+	}
+	line, err := plotter.NewLine(pts)
+	if err != nil {
+		log.Fatalln("plotter.NewLine()", err)
+		return
+	}
+	line.Color = color.RGBA{B: 255, A: 255}
+	line.Width = 2
+	p.Add(line)
+	p.Legend.Add("Optimum", line)
+
+	frontier := sweep.Frontier()
+	fpts := make(plotter.XYs, len(frontier))
+	for i, pt := range frontier {
+		fpts[i].X = pt.Selection.Cost
+		fpts[i].Y = pt.Selection.Value
+	}
+	s, err := plotter.NewScatter(fpts)
+	if err != nil {
+		log.Fatalln("plotter.NewScatter()", err)
+		return
+	}
+	s.GlyphStyle.Color = color.RGBA{R: 255, A: 255}
+	s.GlyphStyle.Radius = vg.Points(3)
+	s.GlyphStyle.Shape = draw.CircleGlyph{}
+	p.Add(s)
+	p.Legend.Add("Pareto frontier", s)
+	p.Legend.Top = true
+	p.Legend.Left = true
+
+	if err := p.Save(8*vg.Inch, 6*vg.Inch, fileName); err != nil {
+		log.Fatalln("plot.Save()", err)
+		return
+	}
+}
+
+func main() {
+	menuPath := flag.String("menu", "../foods.csv", "read the menu from a .csv or .json file")
+	maxBudget := flag.Float64("max", 1500, "largest calorie budget of the sweep")
+	csvPath := flag.String("csv", "sweep.csv", "write the breakpoints to this file")
+	pngPath := flag.String("png", "sweep.png", "plot the sweep to this file")
+	flag.Parse()
+
+	foods, err := menu.Load(*menuPath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	sweep, err := menu.BudgetSweep(foods, *maxBudget)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	fmt.Println("Budget breakpoints of", len(foods), "foods up to", *maxBudget, "calories")
+	for _, pt := range sweep.Breakpoints() {
+		fmt.Printf("%6.0f: %v\n", pt.Budget, pt.Selection)
+	}
+	fmt.Println(len(sweep.Frontier()), "points on the Pareto frontier")
+
+	f, err := os.Create(*csvPath)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer f.Close()
+	if err := sweep.WriteCSV(f); err != nil {
+		log.Fatalln(err)
+	}
+
+	plotSweep(sweep, *pngPath)
+}
@@ -0,0 +1,155 @@
+// Package graph models the graph optimization problems of lecture 3: nodes
+// named like the foods of a menu, weighted edges between them, and the
+// searches for the shortest path from one node to another.
+package graph
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrDuplicateNode = errors.New("graph: duplicate node")
+	ErrUnknownNode   = errors.New("graph: node not in graph")
+)
+
+type Node struct {
+	name string
+}
+
+func (n *Node) Init(name string) { n.name = name }
+
+func (n Node) Name() string { return n.name }
+
+func (n Node) String() string { return n.name }
+
+// Edge goes from src to dest. An unweighted edge has weight 1.
+type Edge struct {
+	src    Node
+	dest   Node
+	weight float64
+}
+
+func (e *Edge) Init(src, dest Node, weight float64) {
+	e.src = src
+	e.dest = dest
+	e.weight = weight
+}
+
+func (e Edge) Src() Node { return e.src }
+
+func (e Edge) Dest() Node { return e.dest }
+
+func (e Edge) Weight() float64 { return e.weight }
+
+func (e Edge) String() string {
+	return fmt.Sprintf("%s->%s (%g)", e.src, e.dest, e.weight)
+}
+
+// Interface is what the searches need of a graph. Both *Digraph and *Graph
+// implement it.
+type Interface interface {
+	Nodes() []Node
+	HasNode(n Node) bool
+	ChildrenOf(n Node) []Edge
+}
+
+// Builder is a graph that edges can be added to, as ReadEdges does.
+type Builder interface {
+	Interface
+	AddNode(n Node) error
+	AddEdge(e Edge) error
+}
+
+// Digraph is a directed graph: the edges leaving each node, in the order
+// they were added.
+type Digraph struct {
+	nodes []Node
+	edges map[Node][]Edge
+}
+
+func (g *Digraph) AddNode(n Node) error {
+	if g.HasNode(n) {
+		return fmt.Errorf("%w %s", ErrDuplicateNode, n)
+	}
+	if g.edges == nil {
+		g.edges = make(map[Node][]Edge)
+	}
+	g.nodes = append(g.nodes, n)
+	g.edges[n] = nil
+	return nil
+}
+
+func (g *Digraph) AddEdge(e Edge) error {
+	for _, n := range []Node{e.src, e.dest} {
+		if !g.HasNode(n) {
+			return fmt.Errorf("%w: %s", ErrUnknownNode, n)
+		}
+	}
+	g.edges[e.src] = append(g.edges[e.src], e)
+	return nil
+}
+
+// ChildrenOf lists the edges leaving n.
+func (g *Digraph) ChildrenOf(n Node) []Edge { return g.edges[n] }
+
+func (g *Digraph) HasNode(n Node) bool {
+	_, ok := g.edges[n]
+	return ok
+}
+
+// GetNode returns the node called name.
+func (g *Digraph) GetNode(name string) (Node, error) {
+	n := Node{name}
+	if !g.HasNode(n) {
+		return Node{}, fmt.Errorf("%w: %s", ErrUnknownNode, name)
+	}
+	return n, nil
+}
+
+// Nodes lists the nodes in the order they were added.
+func (g *Digraph) Nodes() []Node { return g.nodes }
+
+func (g *Digraph) String() string {
+	s := ""
+	for _, src := range g.nodes {
+		for _, e := range g.edges[src] {
+			s += e.String() + "\n"
+		}
+	}
+	return s
+}
+
+// Graph is an undirected graph: every edge added goes both ways.
+type Graph struct {
+	Digraph
+}
+
+func (g *Graph) AddEdge(e Edge) error {
+	if err := g.Digraph.AddEdge(e); err != nil {
+		return err
+	}
+	if e.src == e.dest {
+		return nil
+	}
+	return g.Digraph.AddEdge(Edge{e.dest, e.src, e.weight})
+}
+
+// BuildGraph adds the nodes called names to g, then an edge of weight
+// weights[i] from srcs[i] to dests[i] for each i.
+func BuildGraph(g Builder, names []string, srcs, dests []string, weights []float64) error {
+	if len(srcs) != len(dests) || len(srcs) != len(weights) {
+		return errors.New("graph: srcs, dests and weights differ in length")
+	}
+	for _, name := range names {
+		if err := g.AddNode(Node{name}); err != nil {
+			return err
+		}
+	}
+	for i := range srcs {
+		if err := g.AddEdge(Edge{Node{srcs[i]}, Node{dests[i]}, weights[i]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
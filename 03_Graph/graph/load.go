@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LineError reports a problem with one line of an edge list.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string { return fmt.Sprintf("line %d: %v", e.Line, e.Err) }
+
+func (e *LineError) Unwrap() error { return e.Err }
+
+// LoadEdges reads the edge list file path into g.
+func LoadEdges(path string, g Builder) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return ReadEdges(f, g)
+}
+
+// ReadEdges reads an edge list into g, one edge per line written as
+// "src,dest" or "src,dest,weight", with weight 1 when left out. Nodes are
+// added as they are first named; a line with a single name adds a node
+// with no edges. Blank lines and lines starting with # are skipped. All bad
+// lines are reported together.
+func ReadEdges(r io.Reader, g Builder) error {
+	var errs []error
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, ",")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if err := addLine(g, fields); err != nil {
+			errs = append(errs, &LineError{line, err})
+		}
+	}
+	if err := sc.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func addLine(g Builder, fields []string) error {
+	if len(fields) > 3 {
+		return fmt.Errorf("want src,dest[,weight], got %d fields", len(fields))
+	}
+	weight := 1.0
+	if len(fields) == 3 {
+		w, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil || math.IsNaN(w) || math.IsInf(w, 0) {
+			return fmt.Errorf("weight %q is not a number", fields[2])
+		}
+		weight = w
+	}
+	names := fields
+	if len(names) == 3 {
+		names = names[:2]
+	}
+	var nodes []Node
+	for _, name := range names {
+		if name == "" {
+			return errors.New("empty node name")
+		}
+		n := Node{name}
+		if !g.HasNode(n) {
+			if err := g.AddNode(n); err != nil {
+				return err
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 1 {
+		return nil
+	}
+	return g.AddEdge(Edge{nodes[0], nodes[1], weight})
+}
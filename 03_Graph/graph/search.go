@@ -0,0 +1,251 @@
+package graph
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrNoPath         = errors.New("graph: no path")
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+)
+
+// Path is a walk along the edges of a graph and its total weight.
+type Path struct {
+	Nodes []Node
+	Cost  float64
+}
+
+func (p Path) String() string {
+	var names []string
+	for _, n := range p.Nodes {
+		names = append(names, n.Name())
+	}
+	return strings.Join(names, "->")
+}
+
+// Len is the number of edges of the path.
+func (p Path) Len() int {
+	if len(p.Nodes) == 0 {
+		return 0
+	}
+	return len(p.Nodes) - 1
+}
+
+func (p Path) contains(n Node) bool {
+	for _, m := range p.Nodes {
+		if m == n {
+			return true
+		}
+	}
+	return false
+}
+
+// extend is p followed by e.
+func (p Path) extend(e Edge) Path {
+	nodes := make([]Node, len(p.Nodes), len(p.Nodes)+1)
+	copy(nodes, p.Nodes)
+	return Path{append(nodes, e.dest), p.Cost + e.weight}
+}
+
+// Searcher finds the shortest path from start to end.
+type Searcher interface {
+	ShortestPath(g Interface, start, end Node) (Path, error)
+}
+
+// DFS searches depth first through every path without a cycle, keeping the
+// one of least cost and abandoning any path that already costs as much.
+// Weights must not be negative. When Trace is not nil every path tried is
+// printed to it, as in the lecture.
+type DFS struct {
+	Trace io.Writer
+}
+
+func (s DFS) ShortestPath(g Interface, start, end Node) (Path, error) {
+	if err := check(g, start, end); err != nil {
+		return Path{}, err
+	}
+	if err := nonNegative(g); err != nil {
+		return Path{}, err
+	}
+	var shortest *Path
+	s.dfs(g, Path{Nodes: []Node{start}}, end, &shortest)
+	if shortest == nil {
+		return Path{}, fmt.Errorf("%w from %s to %s", ErrNoPath, start, end)
+	}
+	return *shortest, nil
+}
+
+func (s DFS) dfs(g Interface, path Path, end Node, shortest **Path) {
+	if s.Trace != nil {
+		fmt.Fprintln(s.Trace, "Current DFS path:", path)
+	}
+	last := path.Nodes[len(path.Nodes)-1]
+	if last == end {
+		*shortest = &path
+		return
+	}
+	for _, e := range g.ChildrenOf(last) {
+		if path.contains(e.dest) {
+			// Avoid cycles.
+			if s.Trace != nil {
+				fmt.Fprintln(s.Trace, "Already visited", e.dest)
+			}
+			continue
+		}
+		if *shortest == nil || path.Cost+e.weight < (*shortest).Cost {
+			s.dfs(g, path.extend(e), end, shortest)
+		}
+	}
+}
+
+// BFS searches breadth first, so the path it finds has the fewest edges,
+// whatever they weigh. When Trace is not nil every path dequeued is printed
+// to it.
+type BFS struct {
+	Trace io.Writer
+}
+
+func (s BFS) ShortestPath(g Interface, start, end Node) (Path, error) {
+	if err := check(g, start, end); err != nil {
+		return Path{}, err
+	}
+	queue := []Path{{Nodes: []Node{start}}}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		if s.Trace != nil {
+			fmt.Fprintln(s.Trace, "Current BFS path:", path)
+		}
+		last := path.Nodes[len(path.Nodes)-1]
+		if last == end {
+			return path, nil
+		}
+		for _, e := range g.ChildrenOf(last) {
+			if !path.contains(e.dest) {
+				queue = append(queue, path.extend(e))
+			}
+		}
+	}
+	return Path{}, fmt.Errorf("%w from %s to %s", ErrNoPath, start, end)
+}
+
+// Dijkstra settles the nodes in order of their distance from start, so
+// each is reached once by its cheapest path. Weights must not be negative.
+type Dijkstra struct{}
+
+// reached is a node with the distance it was reached at. The distance is
+// fixed when it is pushed, so that reaching the node again more cheaply
+// pushes a new entry rather than reordering the heap under it.
+type reached struct {
+	node Node
+	dist float64
+}
+
+// frontier is a heap of the nodes reached but not settled, nearest first.
+type frontier []reached
+
+func (f frontier) Len() int            { return len(f) }
+func (f frontier) Less(i, j int) bool  { return f[i].dist < f[j].dist }
+func (f frontier) Swap(i, j int)       { f[i], f[j] = f[j], f[i] }
+func (f *frontier) Push(x interface{}) { *f = append(*f, x.(reached)) }
+func (f *frontier) Pop() interface{} {
+	old := *f
+	r := old[len(old)-1]
+	*f = old[:len(old)-1]
+	return r
+}
+
+func (Dijkstra) ShortestPath(g Interface, start, end Node) (Path, error) {
+	if err := check(g, start, end); err != nil {
+		return Path{}, err
+	}
+	if err := nonNegative(g); err != nil {
+		return Path{}, err
+	}
+	dist := map[Node]float64{start: 0}
+	prev := make(map[Node]Node)
+	settled := make(map[Node]bool)
+	f := &frontier{{start, 0}}
+	for f.Len() > 0 {
+		r := heap.Pop(f).(reached)
+		n := r.node
+		if settled[n] || r.dist > dist[n] {
+			// A stale entry for a node reached again more cheaply.
+			continue
+		}
+		settled[n] = true
+		if n == end {
+			break
+		}
+		for _, e := range g.ChildrenOf(n) {
+			d, ok := dist[e.dest]
+			if alt := dist[n] + e.weight; !settled[e.dest] && (!ok || alt < d) {
+				dist[e.dest] = alt
+				prev[e.dest] = n
+				heap.Push(f, reached{e.dest, alt})
+			}
+		}
+	}
+	if !settled[end] {
+		return Path{}, fmt.Errorf("%w from %s to %s", ErrNoPath, start, end)
+	}
+	nodes := []Node{end}
+	for n := end; n != start; {
+		n = prev[n]
+		nodes = append(nodes, n)
+	}
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+	return Path{nodes, dist[end]}, nil
+}
+
+func check(g Interface, start, end Node) error {
+	for _, n := range []Node{start, end} {
+		if !g.HasNode(n) {
+			return fmt.Errorf("%w: %s", ErrUnknownNode, n)
+		}
+	}
+	return nil
+}
+
+func nonNegative(g Interface) error {
+	for _, n := range g.Nodes() {
+		for _, e := range g.ChildrenOf(n) {
+			if e.weight < 0 {
+				return fmt.Errorf("%w: %v", ErrNegativeWeight, e)
+			}
+		}
+	}
+	return nil
+}
+
+var searchers = map[string]func() Searcher{
+	"dfs":      func() Searcher { return DFS{} },
+	"bfs":      func() Searcher { return BFS{} },
+	"dijkstra": func() Searcher { return Dijkstra{} },
+}
+
+// NewSearcher returns the search registered under name.
+func NewSearcher(name string) (Searcher, error) {
+	newSearcher, ok := searchers[name]
+	if !ok {
+		return nil, fmt.Errorf("graph: unknown search %q (have %s)", name, strings.Join(SearcherNames(), ", "))
+	}
+	return newSearcher(), nil
+}
+
+// SearcherNames lists the registered search names in sorted order.
+func SearcherNames() []string {
+	var names []string
+	for name := range searchers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randomGraph has n nodes named "0" to "n-1" and each edge with probability
+// p, weighing a whole number from 0 to 9. An undirected graph has at most
+// one edge between two nodes.
+func randomGraph(t *testing.T, r *rand.Rand, n int, p float64, undirected bool) (Builder, []Node) {
+	var g Builder = &Digraph{}
+	if undirected {
+		g = &Graph{}
+	}
+	nodes := make([]Node, n)
+	for i := range nodes {
+		nodes[i].Init(fmt.Sprint(i))
+		if err := g.AddNode(nodes[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := range nodes {
+		for j := range nodes {
+			if (i < j || i > j && !undirected) && r.Float64() < p {
+				var e Edge
+				e.Init(nodes[i], nodes[j], float64(r.Intn(10)))
+				if err := g.AddEdge(e); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+	return g, nodes
+}
+
+// floydWarshall is the least total of weight(e) over the edges of any path
+// between each pair of nodes, +Inf if there is none.
+func floydWarshall(g Interface, nodes []Node, weight func(e Edge) float64) [][]float64 {
+	index := make(map[Node]int)
+	for i, n := range nodes {
+		index[n] = i
+	}
+	dist := make([][]float64, len(nodes))
+	for i := range dist {
+		dist[i] = make([]float64, len(nodes))
+		for j := range dist[i] {
+			if i != j {
+				dist[i][j] = math.Inf(1)
+			}
+		}
+		for _, e := range g.ChildrenOf(nodes[i]) {
+			j := index[e.Dest()]
+			dist[i][j] = math.Min(dist[i][j], weight(e))
+		}
+	}
+	for k := range nodes {
+		for i := range nodes {
+			for j := range nodes {
+				dist[i][j] = math.Min(dist[i][j], dist[i][k]+dist[k][j])
+			}
+		}
+	}
+	return dist
+}
+
+// checkPath reports whether path walks edges of g from start to end and
+// costs what they weigh.
+func checkPath(g Interface, path Path, start, end Node) error {
+	if len(path.Nodes) == 0 || path.Nodes[0] != start || path.Nodes[len(path.Nodes)-1] != end {
+		return fmt.Errorf("path %v does not go from %s to %s", path, start, end)
+	}
+	cost := 0.0
+	for i := 1; i < len(path.Nodes); i++ {
+		w := math.Inf(1)
+		for _, e := range g.ChildrenOf(path.Nodes[i-1]) {
+			if e.Dest() == path.Nodes[i] {
+				w = math.Min(w, e.Weight())
+			}
+		}
+		if math.IsInf(w, 1) {
+			return fmt.Errorf("path %v has no edge %s->%s", path, path.Nodes[i-1], path.Nodes[i])
+		}
+		cost += w
+	}
+	if cost != path.Cost {
+		return fmt.Errorf("path %v costs %v, not %v", path, cost, path.Cost)
+	}
+	return nil
+}
+
+func TestSearchersMatchFloydWarshall(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for k := 0; k < 500; k++ {
+		g, nodes := randomGraph(t, r, 2+r.Intn(6), r.Float64(), k%3 == 0)
+		cost := floydWarshall(g, nodes, Edge.Weight)
+		hops := floydWarshall(g, nodes, func(Edge) float64 { return 1 })
+		for i, start := range nodes {
+			for j, end := range nodes {
+				for _, name := range SearcherNames() {
+					s, err := NewSearcher(name)
+					if err != nil {
+						t.Fatal(err)
+					}
+					path, err := s.ShortestPath(g, start, end)
+					if math.IsInf(cost[i][j], 1) {
+						if !errors.Is(err, ErrNoPath) {
+							t.Fatalf("%v: %s from %s to %s: got %v, %v, want ErrNoPath", g, name, start, end, path, err)
+						}
+						continue
+					}
+					if err != nil {
+						t.Fatalf("%v: %s from %s to %s: %v", g, name, start, end, err)
+					}
+					if err := checkPath(g, path, start, end); err != nil {
+						t.Fatalf("%v: %s: %v", g, name, err)
+					}
+					if name == "bfs" {
+						if float64(path.Len()) != hops[i][j] {
+							t.Fatalf("%v: bfs from %s to %s: %v has %d edges, want %v", g, start, end, path, path.Len(), hops[i][j])
+						}
+					} else if path.Cost != cost[i][j] {
+						t.Fatalf("%v: %s from %s to %s: %v costs %v, want %v", g, name, start, end, path, path.Cost, cost[i][j])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSearchersRejectNegativeWeights(t *testing.T) {
+	g := &Digraph{}
+	var a, b Node
+	a.Init("a")
+	b.Init("b")
+	g.AddNode(a)
+	g.AddNode(b)
+	var e Edge
+	e.Init(a, b, -1)
+	g.AddEdge(e)
+	for _, s := range []Searcher{DFS{}, Dijkstra{}} {
+		if _, err := s.ShortestPath(g, a, b); !errors.Is(err, ErrNegativeWeight) {
+			t.Errorf("%T: got %v, want ErrNegativeWeight", s, err)
+		}
+	}
+}
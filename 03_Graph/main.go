@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"./graph"
+)
+
+// buildCityGraph adds the cities of the lecture and the flights between
+// them to g, each weighing its distance in miles.
+func buildCityGraph(g graph.Builder) error {
+	names := []string{"Boston", "Providence", "New York", "Chicago", "Denver", "Phoenix", "Los Angeles"}
+	srcs := []string{"Boston", "Boston", "Providence", "Providence", "New York", "Chicago", "Chicago", "Denver", "Denver", "Los Angeles"}
+	dests := []string{"Providence", "New York", "Boston", "New York", "Chicago", "Denver", "Phoenix", "Phoenix", "New York", "Boston"}
+	weights := []float64{50, 215, 50, 180, 790, 1000, 1750, 820, 1780, 2990}
+	return graph.BuildGraph(g, names, srcs, dests, weights)
+}
+
+func testSP(g graph.Interface, name string, s graph.Searcher, source, destination graph.Node) {
+	fmt.Printf("Shortest path from %s to %s by %s\n", source, destination, name)
+	sp, err := s.ShortestPath(g, source, destination)
+	if err != nil {
+		fmt.Println("   ", err)
+		return
+	}
+	fmt.Printf("    %s, %d edges, cost %g\n", sp, sp.Len(), sp.Cost)
+}
+
+func main() {
+	graphPath := flag.String("graph", "", "read the graph from an edge list file of src,dest,weight lines")
+	undirected := flag.Bool("undirected", false, "make every edge go both ways")
+	from := flag.String("from", "Boston", "node to start from")
+	to := flag.String("to", "Phoenix", "node to reach")
+	search := flag.String("search", "", "search to use: bfs, dfs or dijkstra (default all of them)")
+	trace := flag.Bool("trace", false, "print every path tried by dfs and bfs")
+	flag.Parse()
+
+	var g graph.Builder = &graph.Digraph{}
+	if *undirected {
+		g = &graph.Graph{}
+	}
+	var err error
+	if *graphPath != "" {
+		err = graph.LoadEdges(*graphPath, g)
+	} else {
+		err = buildCityGraph(g)
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Print(g)
+	fmt.Println()
+
+	var source, destination graph.Node
+	source.Init(*from)
+	destination.Init(*to)
+
+	names := graph.SearcherNames()
+	if *search != "" {
+		names = []string{*search}
+	}
+	for _, name := range names {
+		s, err := graph.NewSearcher(name)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		if *trace {
+			switch name {
+			case "dfs":
+				s = graph.DFS{Trace: os.Stdout}
+			case "bfs":
+				s = graph.BFS{Trace: os.Stdout}
+			}
+		}
+		testSP(g, name, s, source, destination)
+	}
+}
@@ -0,0 +1,118 @@
+// Package alias draws from a discrete distribution of given weights with
+// the alias method of Vose, "A linear algorithm for generating random
+// numbers with a given distribution" (1991): the table is built once in
+// O(n), after which every draw takes one uniform column and one coin flip.
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+var (
+	ErrEmpty     = errors.New("alias: no weights")
+	ErrBadWeight = errors.New("alias: weights must be non-negative and not all zero")
+)
+
+// Table draws outcome i in proportion to weights[i]. Column i of the table
+// keeps i with probability prob[i] and otherwise gives alias[i].
+type Table struct {
+	weights []float64
+	total   float64
+	prob    []float64
+	alias   []int
+}
+
+// New returns the table of weights, which must be non-negative and not all
+// zero. Packages drawing with weights leave checking them to New and wrap
+// its errors, so that a bad weight is ErrBadWeight wherever it is found.
+func New(weights []float64) (*Table, error) {
+	t := new(Table)
+	if err := t.Init(weights); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Init builds the table of weights.
+func (t *Table) Init(weights []float64) error {
+	n := len(weights)
+	if n == 0 {
+		return ErrEmpty
+	}
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return fmt.Errorf("%w, got %v", ErrBadWeight, weights)
+		}
+		total += w
+	}
+	if total == 0 {
+		return fmt.Errorf("%w, got %v", ErrBadWeight, weights)
+	}
+
+	t.weights = append([]float64(nil), weights...)
+	t.total = total
+	t.prob = make([]float64, n)
+	t.alias = make([]int, n)
+
+	// Scale the weights to average 1, then fill each column that is
+	// short of 1 from one that has more.
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		t.prob[s] = scaled[s]
+		t.alias[s] = l
+		scaled[l] = scaled[l] + scaled[s] - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	// What is left is 1 but for rounding, except that an outcome of
+	// weight 0 must never be kept.
+	top := 0
+	for i, w := range weights {
+		if w > weights[top] {
+			top = i
+		}
+	}
+	for _, i := range append(large, small...) {
+		t.prob[i] = 1
+		if weights[i] == 0 {
+			t.prob[i], t.alias[i] = 0, top
+		}
+	}
+	return nil
+}
+
+// Len is the number of outcomes.
+func (t *Table) Len() int { return len(t.prob) }
+
+// Prob is the probability of outcome i.
+func (t *Table) Prob(i int) float64 { return t.weights[i] / t.total }
+
+// Draw returns an outcome drawn with r.
+func (t *Table) Draw(r *rand.Rand) int {
+	i := r.Intn(len(t.prob))
+	if r.Float64() < t.prob[i] {
+		return i
+	}
+	return t.alias[i]
+}
@@ -0,0 +1,92 @@
+package alias
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New(nil); !errors.Is(err, ErrEmpty) {
+		t.Errorf("New(nil): got %v, want ErrEmpty", err)
+	}
+	for _, w := range [][]float64{{0, 0}, {1, -1}, {1, math.NaN()}, {math.Inf(1)}} {
+		if _, err := New(w); !errors.Is(err, ErrBadWeight) {
+			t.Errorf("New(%v): got %v, want ErrBadWeight", w, err)
+		}
+	}
+}
+
+func TestProb(t *testing.T) {
+	tab, err := New([]float64{1, 0, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tab.Len() != 3 || tab.Prob(0) != 0.25 || tab.Prob(1) != 0 || tab.Prob(2) != 0.75 {
+		t.Errorf("Len() = %d, Prob() = %v %v %v", tab.Len(), tab.Prob(0), tab.Prob(1), tab.Prob(2))
+	}
+}
+
+// TestDraw draws from random weights, some of them 0, and checks that each
+// outcome comes up as often as its weight says, within four standard
+// deviations, and that an outcome of weight 0 never does.
+func TestDraw(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	const draws = 100000
+	for k := 0; k < 20; k++ {
+		weights := make([]float64, 1+r.Intn(10))
+		for i := range weights {
+			if r.Intn(3) > 0 {
+				weights[i] = r.Float64() * 10
+			}
+		}
+		weights[r.Intn(len(weights))] = 1
+		tab, err := New(weights)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts := make([]int, len(weights))
+		for i := 0; i < draws; i++ {
+			counts[tab.Draw(r)]++
+		}
+		for i, n := range counts {
+			p := tab.Prob(i)
+			if weights[i] == 0 && n > 0 {
+				t.Errorf("%v: drew outcome %d of weight 0 %d times", weights, i, n)
+			}
+			if got := float64(n) / draws; math.Abs(got-p) > 4*math.Sqrt(p*(1-p)/draws) {
+				t.Errorf("%v: outcome %d drawn %v of the time, want %v", weights, i, got, p)
+			}
+		}
+	}
+}
+
+// TestTableSumsToWeights checks the table exactly: the probability that
+// Draw gives i, summed over the columns, is Prob(i).
+func TestTableSumsToWeights(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for k := 0; k < 200; k++ {
+		weights := make([]float64, 1+r.Intn(20))
+		for i := range weights {
+			if r.Intn(4) > 0 {
+				weights[i] = float64(r.Intn(100))
+			}
+		}
+		weights[0]++
+		tab, err := New(weights)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := make([]float64, tab.Len())
+		for i := range tab.prob {
+			got[i] += tab.prob[i] / float64(tab.Len())
+			got[tab.alias[i]] += (1 - tab.prob[i]) / float64(tab.Len())
+		}
+		for i := range got {
+			if math.Abs(got[i]-tab.Prob(i)) > 1e-12 {
+				t.Fatalf("%v: outcome %d has probability %v in the table, want %v", weights, i, got[i], tab.Prob(i))
+			}
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// Package birthday computes the probability that at least k of n people
+// share a birthday, on calendars where every day is as likely or where
+// days have weights, both exactly and by simulation.
+package birthday
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"../alias"
+)
+
+var (
+	ErrNoDays   = errors.New("birthday: a calendar needs at least one day")
+	ErrBadGroup = errors.New("birthday: want 0 or more people and 1 or more sharing")
+)
+
+// precision is the number of mantissa bits of the exact computation.
+const precision = 256
+
+// Calendar is a list of days, a birthday falling on day i with probability
+// Prob(i).
+type Calendar struct {
+	days    []string
+	weights []float64
+	total   float64
+	probs   []float64
+	table   *alias.Table
+}
+
+// Init makes c a calendar of days days, all as likely, named "1" to days.
+func (c *Calendar) Init(days int) error {
+	if days < 1 {
+		return fmt.Errorf("%w, got %d", ErrNoDays, days)
+	}
+	names := make([]string, days)
+	weights := make([]float64, days)
+	for i := range names {
+		names[i] = strconv.Itoa(i + 1)
+		weights[i] = 1
+	}
+	return c.InitWeighted(names, weights)
+}
+
+// InitWeighted makes c a calendar of the days, a birthday falling on day i
+// in proportion to weights[i]. Bad weights are alias.ErrBadWeight.
+func (c *Calendar) InitWeighted(days []string, weights []float64) error {
+	if len(days) == 0 {
+		return ErrNoDays
+	}
+	if len(days) != len(weights) {
+		return fmt.Errorf("birthday: %d days and %d weights", len(days), len(weights))
+	}
+	t, err := alias.New(weights)
+	if err != nil {
+		return fmt.Errorf("birthday: %w", err)
+	}
+	c.table = t
+	c.days = append([]string(nil), days...)
+	c.weights = append([]float64(nil), weights...)
+	c.total = 0
+	for _, w := range weights {
+		c.total += w
+	}
+	c.probs = make([]float64, len(weights))
+	for i := range weights {
+		c.probs[i] = t.Prob(i)
+	}
+	return nil
+}
+
+// LoadCalendar reads a calendar from a file of day,weight lines.
+func LoadCalendar(path string) (Calendar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Calendar{}, err
+	}
+	defer f.Close()
+	return ReadCalendar(f)
+}
+
+// ReadCalendar reads a calendar written one day per line as "day,weight",
+// the weight being how often birthdays fall on the day relative to the
+// others, e.g. a count of births. Blank lines and lines starting with #
+// are skipped.
+func ReadCalendar(r io.Reader) (Calendar, error) {
+	var days []string
+	var weights []float64
+	var errs []error
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		day, weight, ok := strings.Cut(text, ",")
+		if !ok {
+			errs = append(errs, fmt.Errorf("line %d: want day,weight", line))
+			continue
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(weight), 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %v", line, err))
+			continue
+		}
+		days = append(days, strings.TrimSpace(day))
+		weights = append(weights, w)
+	}
+	if err := sc.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return Calendar{}, fmt.Errorf("birthday: %w", errors.Join(errs...))
+	}
+	var c Calendar
+	err := c.InitWeighted(days, weights)
+	return c, err
+}
+
+func (c Calendar) Days() int { return len(c.probs) }
+
+// Name is the name of day i, counting from 0.
+func (c Calendar) Name(i int) string { return c.days[i] }
+
+// Prob is the probability of a birthday on day i, counting from 0.
+func (c Calendar) Prob(i int) float64 { return c.probs[i] }
+
+// Uniform reports whether every day is as likely.
+func (c Calendar) Uniform() bool {
+	for _, p := range c.probs {
+		if p != c.probs[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// Day draws a day with r.
+func (c Calendar) Day(r *rand.Rand) int { return c.table.Draw(r) }
+
+func (c Calendar) String() string {
+	if c.Uniform() {
+		return fmt.Sprintf("%d equally likely days", c.Days())
+	}
+	return fmt.Sprintf("%d weighted days", c.Days())
+}
+
+// SameDate reports whether at least numSame of numPeople born on days
+// drawn with r share a day.
+func (c Calendar) SameDate(numPeople, numSame int, r *rand.Rand) bool {
+	birthdays := make([]int, c.Days())
+	for p := 0; p < numPeople; p++ {
+		d := c.Day(r)
+		birthdays[d]++
+		if birthdays[d] >= numSame {
+			return true
+		}
+	}
+	return numSame <= 0
+}
+
+// Simulate estimates the probability that at least numSame of numPeople
+// share a birthday from numTrials groups.
+func (c Calendar) Simulate(numPeople, numSame, numTrials int, r *rand.Rand) float64 {
+	numHits := 0
+	for t := 0; t < numTrials; t++ {
+		if c.SameDate(numPeople, numSame, r) {
+			numHits++
+		}
+	}
+	return float64(numHits) / float64(numTrials)
+}
+
+// Exact is the probability that at least numSame of numPeople share a
+// birthday. The chance that no day has numSame birthdays is n! times the
+// coefficient of x^n in the product over days d of
+// sum_{j<numSame} (p_d x)^j / j!, which is computed here in big.Float,
+// raising the factor of each distinct probability to the number of days
+// that have it. numPeople must not be negative and numSame must be at
+// least 1, or the error is ErrBadGroup.
+func (c Calendar) Exact(numPeople, numSame int) (*big.Float, error) {
+	if numPeople < 0 || numSame < 1 {
+		return nil, fmt.Errorf("%w, got %d people and %d sharing", ErrBadGroup, numPeople, numSame)
+	}
+	counts := make(map[float64]int)
+	for _, w := range c.weights {
+		counts[w]++
+	}
+	weights := make([]float64, 0, len(counts))
+	for w := range counts {
+		weights = append(weights, w)
+	}
+	sort.Float64s(weights)
+
+	n := numPeople
+	none := poly(n)
+	none[0].SetInt64(1)
+	for _, w := range weights {
+		// factor[j] = p^j / j! for j < numSame, with p the weight over
+		// the total, divided here rather than in float64.
+		factor := poly(n)
+		term := newFloat(1)
+		p := newFloat(w)
+		p.Quo(p, newFloat(c.total))
+		for j := 0; j < numSame && j <= n; j++ {
+			factor[j].Set(term)
+			term.Mul(term, p)
+			term.Quo(term, newFloat(float64(j+1)))
+		}
+		none = mul(none, power(factor, counts[w], n), n)
+	}
+	fact := new(big.Float).SetPrec(precision).SetInt(new(big.Int).MulRange(1, int64(n)))
+	prob := new(big.Float).SetPrec(precision).Mul(fact, none[n])
+	return prob.Sub(newFloat(1), prob), nil
+}
+
+func newFloat(x float64) *big.Float {
+	return new(big.Float).SetPrec(precision).SetFloat64(x)
+}
+
+// poly is a polynomial of degree n with every coefficient 0.
+func poly(n int) []*big.Float {
+	a := make([]*big.Float, n+1)
+	for i := range a {
+		a[i] = newFloat(0)
+	}
+	return a
+}
+
+// mul is a times b, without the terms of degree above n.
+func mul(a, b []*big.Float, n int) []*big.Float {
+	c := poly(n)
+	t := newFloat(0)
+	for i, x := range a {
+		if x.Sign() == 0 {
+			continue
+		}
+		for j := 0; i+j <= n; j++ {
+			c[i+j].Add(c[i+j], t.Mul(x, b[j]))
+		}
+	}
+	return c
+}
+
+// power is a to the k, without the terms of degree above n.
+func power(a []*big.Float, k, n int) []*big.Float {
+	result := poly(n)
+	result[0].SetInt64(1)
+	for ; k > 0; k >>= 1 {
+		if k&1 == 1 {
+			result = mul(result, a, n)
+		}
+		if k > 1 {
+			a = mul(a, a, n)
+		}
+	}
+	return result
+}
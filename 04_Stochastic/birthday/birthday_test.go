@@ -0,0 +1,158 @@
+package birthday
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"../alias"
+)
+
+func uniform(t *testing.T, days int) Calendar {
+	t.Helper()
+	var c Calendar
+	if err := c.Init(days); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func exact(t *testing.T, c Calendar, numPeople, numSame int) float64 {
+	t.Helper()
+	p, err := c.Exact(numPeople, numSame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, _ := p.Float64()
+	return f
+}
+
+func TestExactPairs(t *testing.T) {
+	c := uniform(t, 365)
+	if p := exact(t, c, 23, 2); math.Abs(p-0.507297) > 1e-6 {
+		t.Errorf("23 people: %v, want 0.507297", p)
+	}
+	// 88 is the fewest people for better than even odds of three sharing.
+	if p, q := exact(t, c, 87, 3), exact(t, c, 88, 3); p >= 0.5 || q <= 0.5 {
+		t.Errorf("three sharing: %v for 87 people, %v for 88", p, q)
+	}
+	// No two of n share a day with probability 365/365 * 364/365 * ...
+	none := 1.0
+	for n := 0; n <= 100; n++ {
+		if p := exact(t, c, n, 2); math.Abs(p-(1-none)) > 1e-12 {
+			t.Errorf("%d people: %v, want %v", n, p, 1-none)
+		}
+		none *= float64(365-n) / 365
+	}
+}
+
+func TestExactOneSharing(t *testing.T) {
+	c := uniform(t, 365)
+	if p := exact(t, c, 0, 1); p != 0 {
+		t.Errorf("no people: %v, want 0", p)
+	}
+	if p := exact(t, c, 1, 1); p != 1 {
+		t.Errorf("1 person: %v, want 1", p)
+	}
+}
+
+// bruteExact is the probability that at least numSame of numPeople share
+// a day of c, by enumerating every assignment of birthdays.
+func bruteExact(c Calendar, numPeople, numSame int) float64 {
+	counts := make([]int, c.Days())
+	var try func(i int, p float64) float64
+	try = func(i int, p float64) float64 {
+		if i == numPeople {
+			for _, n := range counts {
+				if n >= numSame {
+					return p
+				}
+			}
+			return 0
+		}
+		total := 0.0
+		for d := range counts {
+			counts[d]++
+			total += try(i+1, p*c.Prob(d))
+			counts[d]--
+		}
+		return total
+	}
+	return try(0, 1)
+}
+
+func TestExactMatchesEnumeration(t *testing.T) {
+	var weighted Calendar
+	if err := weighted.InitWeighted([]string{"a", "b", "c", "d"}, []float64{1, 2, 2, 0.5}); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []Calendar{uniform(t, 5), weighted} {
+		for n := 0; n <= 7; n++ {
+			for k := 1; k <= 4; k++ {
+				want := bruteExact(c, n, k)
+				if p := exact(t, c, n, k); math.Abs(p-want) > 1e-12 {
+					t.Errorf("%v, %d people, %d sharing: %v, want %v", c, n, k, p, want)
+				}
+			}
+		}
+	}
+}
+
+func TestExactBadGroup(t *testing.T) {
+	c := uniform(t, 365)
+	for _, g := range [][2]int{{-1, 2}, {10, 0}} {
+		if _, err := c.Exact(g[0], g[1]); !errors.Is(err, ErrBadGroup) {
+			t.Errorf("Exact(%d, %d): got %v, want ErrBadGroup", g[0], g[1], err)
+		}
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var c Calendar
+	if err := c.InitWeighted([]string{"a", "b", "c"}, []float64{1, 1, 4}); err != nil {
+		t.Fatal(err)
+	}
+	want := exact(t, c, 5, 3)
+	// Four standard deviations of 100000 trials.
+	if p := c.Simulate(5, 3, 100000, r); math.Abs(p-want) > 4*math.Sqrt(want*(1-want)/100000) {
+		t.Errorf("Simulate() = %v, exact %v", p, want)
+	}
+}
+
+func TestReadCalendar(t *testing.T) {
+	c, err := ReadCalendar(strings.NewReader("# days\n\nmon, 1\ntue,3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Days() != 2 || c.Name(0) != "mon" || c.Prob(1) != 0.75 || c.Uniform() {
+		t.Errorf("got %v: %s %v, %s %v", c, c.Name(0), c.Prob(0), c.Name(1), c.Prob(1))
+	}
+
+	_, err = ReadCalendar(strings.NewReader("mon,1\ntue\nwed,x\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("got %v, want errors on lines 2 and 3", err)
+	}
+	if _, err := ReadCalendar(strings.NewReader("# nothing\n")); !errors.Is(err, ErrNoDays) {
+		t.Errorf("no days: got %v, want ErrNoDays", err)
+	}
+	if _, err := ReadCalendar(strings.NewReader("mon,1\ntue,-1\n")); !errors.Is(err, alias.ErrBadWeight) {
+		t.Errorf("negative weight: got %v, want alias.ErrBadWeight", err)
+	}
+}
+
+func TestLoadCalendar(t *testing.T) {
+	c, err := LoadCalendar("../birthdays.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Days() != 366 || c.Uniform() {
+		t.Errorf("got %v, want 366 weighted days", c)
+	}
+	// Uneven birthdays are shared sooner than even ones.
+	if even, uneven := exact(t, uniform(t, 366), 23, 2), exact(t, c, 23, 2); uneven <= even {
+		t.Errorf("23 people: %v on %v, not above %v on even days", uneven, c, even)
+	}
+}
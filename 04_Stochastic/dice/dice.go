@@ -0,0 +1,102 @@
+// Package dice models the dice of lecture 4: dice of any number of faces,
+// fair or loaded, goals on a sequence of rolls, and the probability of a
+// goal both computed exactly and estimated by simulation.
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"../alias"
+)
+
+var ErrNoFaces = errors.New("dice: a die needs at least one face")
+
+// Die has faces numbered 1 to Faces, face i coming up with probability
+// Prob(i).
+type Die struct {
+	probs []float64 // probs[i] is the probability of face i+1
+	table *alias.Table
+}
+
+// Init makes d a fair die of faces faces.
+func (d *Die) Init(faces int) error {
+	if faces < 1 {
+		return fmt.Errorf("%w, got %d", ErrNoFaces, faces)
+	}
+	weights := make([]float64, faces)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return d.InitWeighted(weights)
+}
+
+// InitWeighted makes d a loaded die, face i+1 coming up in proportion to
+// weights[i]. Bad weights are alias.ErrBadWeight.
+func (d *Die) InitWeighted(weights []float64) error {
+	if len(weights) == 0 {
+		return ErrNoFaces
+	}
+	t, err := alias.New(weights)
+	if err != nil {
+		return fmt.Errorf("dice: %w", err)
+	}
+	d.table = t
+	d.probs = make([]float64, t.Len())
+	for i := range d.probs {
+		d.probs[i] = t.Prob(i)
+	}
+	return nil
+}
+
+// ParseWeights reads the weights of a loaded die written as "1,1,1,1,1,3".
+func ParseWeights(s string) ([]float64, error) {
+	var weights []float64
+	for _, part := range strings.Split(s, ",") {
+		w, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("dice: weights %q: %v", s, err)
+		}
+		weights = append(weights, w)
+	}
+	return weights, nil
+}
+
+func (d Die) Faces() int { return len(d.probs) }
+
+// Prob is the probability of face, 0 for a face the die does not have.
+func (d Die) Prob(face int) float64 {
+	if face < 1 || face > len(d.probs) {
+		return 0
+	}
+	return d.probs[face-1]
+}
+
+// Fair reports whether every face is as likely.
+func (d Die) Fair() bool {
+	for _, p := range d.probs {
+		if p != d.probs[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// Roll returns a face drawn with r.
+func (d Die) Roll(r *rand.Rand) int {
+	return d.table.Draw(r) + 1
+}
+
+func (d Die) String() string {
+	if d.Fair() {
+		return fmt.Sprintf("fair %d-sided die", d.Faces())
+	}
+	var parts []string
+	for _, p := range d.probs {
+		parts = append(parts, fmt.Sprintf("%.3g", p))
+	}
+	return fmt.Sprintf("loaded %d-sided die (%s)", d.Faces(), strings.Join(parts, " "))
+}
@@ -0,0 +1,160 @@
+package dice
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"../alias"
+)
+
+func fair(t *testing.T, faces int) Die {
+	t.Helper()
+	var d Die
+	if err := d.Init(faces); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func loaded(t *testing.T, weights ...float64) Die {
+	t.Helper()
+	var d Die
+	if err := d.InitWeighted(weights); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestDie(t *testing.T) {
+	d := fair(t, 6)
+	if !d.Fair() || d.Faces() != 6 || d.Prob(3) != 1.0/6 || d.Prob(0) != 0 || d.Prob(7) != 0 {
+		t.Errorf("%v: Faces() = %d, Prob(3) = %v", d, d.Faces(), d.Prob(3))
+	}
+	d = loaded(t, 1, 1, 1, 1, 1, 3)
+	if d.Fair() || d.Prob(6) != 3.0/8 || d.Prob(1) != 1.0/8 {
+		t.Errorf("%v: Prob(6) = %v, Prob(1) = %v", d, d.Prob(6), d.Prob(1))
+	}
+}
+
+func TestDieErrors(t *testing.T) {
+	var d Die
+	if err := d.Init(0); !errors.Is(err, ErrNoFaces) {
+		t.Errorf("Init(0): got %v, want ErrNoFaces", err)
+	}
+	if err := d.InitWeighted(nil); !errors.Is(err, ErrNoFaces) {
+		t.Errorf("InitWeighted(nil): got %v, want ErrNoFaces", err)
+	}
+	for _, w := range [][]float64{{1, -1}, {0, 0}, {math.NaN()}} {
+		if err := d.InitWeighted(w); !errors.Is(err, alias.ErrBadWeight) {
+			t.Errorf("InitWeighted(%v): got %v, want alias.ErrBadWeight", w, err)
+		}
+	}
+}
+
+func TestExact(t *testing.T) {
+	d := fair(t, 6)
+	for _, c := range []struct {
+		g    Goal
+		want float64
+	}{
+		{Sequence(1, 1, 1, 1, 1), 1.0 / 7776},
+		{SumAtLeast(2, 12), 1.0 / 36},
+		{SumAtLeast(2, 2), 1},
+		{AtLeast(4, 1, 6), 1 - math.Pow(5.0/6, 4)},
+		{AtLeast(2, 2, 6), 1.0 / 36},
+	} {
+		got, err := Exact(d, c.g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got-c.want) > 1e-12 {
+			t.Errorf("Exact(%v) = %v, want %v", c.g, got, c.want)
+		}
+	}
+}
+
+// TestExactMatchesEnumeration checks the dynamic program of each goal
+// against enumerating every sequence of rolls with the same test.
+func TestExactMatchesEnumeration(t *testing.T) {
+	for _, d := range []Die{fair(t, 6), loaded(t, 1, 0, 2, 1, 1, 3), loaded(t, 5, 1, 1)} {
+		for _, g := range []Goal{
+			Sequence(3, 1, 3),
+			SumAtLeast(4, 13),
+			SumAtLeast(3, 0),
+			AtLeast(5, 2, 3),
+			AtLeast(3, 0, 1),
+		} {
+			want, err := Exact(d, Predicate(g.String(), g.Rolls, g.Test))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Exact(d, g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-want) > 1e-12 {
+				t.Errorf("%v: Exact(%v) = %v, enumeration gives %v", d, g, got, want)
+			}
+		}
+	}
+}
+
+func TestExactTooManyOutcomes(t *testing.T) {
+	g := Predicate("anything", 20, func([]int) bool { return true })
+	if _, err := Exact(fair(t, 6), g); !errors.Is(err, ErrTooManyOutcomes) {
+		t.Errorf("got %v, want ErrTooManyOutcomes", err)
+	}
+}
+
+func TestParseGoal(t *testing.T) {
+	for s, want := range map[string]string{
+		"11111":             "rolls 1,1,1,1,1",
+		" 10, 12,1":         "rolls 10,12,1",
+		"sum>=20 in 5":      "sum of 5 rolls >= 20",
+		"count 6 >= 2 in 5": "at least 2 6s in 5 rolls",
+	} {
+		g, err := ParseGoal(s)
+		if err != nil {
+			t.Errorf("ParseGoal(%q): %v", s, err)
+			continue
+		}
+		if g.String() != want {
+			t.Errorf("ParseGoal(%q) = %v, want %v", s, g, want)
+		}
+	}
+	for _, s := range []string{"", "1a", "1,,2", "sum>=20 in 0", "sum=20 in 5", "max>=3 in 2", "count x>=2 in 5"} {
+		if _, err := ParseGoal(s); err == nil {
+			t.Errorf("ParseGoal(%q) did not fail", s)
+		}
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	d := loaded(t, 1, 1, 1, 1, 1, 3)
+	for _, g := range []Goal{AtLeast(4, 2, 6), SumAtLeast(3, 12), Sequence(6, 6)} {
+		want, err := Exact(d, g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		e := Simulate(d, g, 100000, r)
+		// Four standard deviations should hold on any seed.
+		if lo, hi := e.Interval(4); want < lo || want > hi {
+			t.Errorf("Simulate(%v) = %v, exact %v", g, e, want)
+		}
+	}
+}
+
+func TestInterval(t *testing.T) {
+	for _, e := range []Estimate{{0, 100}, {100, 100}, {37, 100}} {
+		lo, hi := e.Interval(1.96)
+		if lo < 0 || hi > 1 || lo >= hi || e.P() < lo-1e-12 || e.P() > hi+1e-12 {
+			t.Errorf("%v: interval %v to %v", e, lo, hi)
+		}
+	}
+	if lo, hi := (Estimate{}).Interval(1.96); lo != 0 || hi != 1 {
+		t.Errorf("no trials: interval %v to %v, want 0 to 1", lo, hi)
+	}
+}
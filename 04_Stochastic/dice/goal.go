@@ -0,0 +1,222 @@
+package dice
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrTooManyOutcomes = errors.New("dice: too many outcomes to enumerate")
+
+// maxOutcomes bounds the sequences of rolls Exact enumerates.
+const maxOutcomes = 1 << 24
+
+// Goal is an event on a sequence of Rolls rolls of a die.
+type Goal struct {
+	Rolls int
+	name  string
+	test  func(rolls []int) bool
+	exact func(d Die) float64 // nil if the goal has no faster way than enumeration
+}
+
+func (g Goal) String() string { return g.name }
+
+// Test reports whether the rolls meet the goal.
+func (g Goal) Test(rolls []int) bool { return g.test(rolls) }
+
+// Sequence is the goal of rolling exactly these faces in this order.
+func Sequence(faces ...int) Goal {
+	want := append([]int(nil), faces...)
+	var parts []string
+	for _, f := range want {
+		parts = append(parts, strconv.Itoa(f))
+	}
+	return Goal{
+		Rolls: len(want),
+		name:  "rolls " + strings.Join(parts, ","),
+		test: func(rolls []int) bool {
+			for i, f := range want {
+				if rolls[i] != f {
+					return false
+				}
+			}
+			return true
+		},
+		exact: func(d Die) float64 {
+			p := 1.0
+			for _, f := range want {
+				p *= d.Prob(f)
+			}
+			return p
+		},
+	}
+}
+
+// SumAtLeast is the goal of n rolls adding up to total or more.
+func SumAtLeast(n, total int) Goal {
+	return Goal{
+		Rolls: n,
+		name:  fmt.Sprintf("sum of %d rolls >= %d", n, total),
+		test: func(rolls []int) bool {
+			sum := 0
+			for _, f := range rolls {
+				sum += f
+			}
+			return sum >= total
+		},
+		exact: func(d Die) float64 {
+			if total <= 0 {
+				return 1
+			}
+			// dist[s] is the probability that the rolls so far add up
+			// to s.
+			dist := []float64{1}
+			for i := 0; i < n; i++ {
+				next := make([]float64, len(dist)+d.Faces())
+				for s, p := range dist {
+					for f := 1; f <= d.Faces(); f++ {
+						next[s+f] += p * d.Prob(f)
+					}
+				}
+				dist = next
+			}
+			p := 0.0
+			for s := total; s < len(dist); s++ {
+				p += dist[s]
+			}
+			return p
+		},
+	}
+}
+
+// AtLeast is the goal of face coming up count times or more in n rolls.
+func AtLeast(n, count, face int) Goal {
+	return Goal{
+		Rolls: n,
+		name:  fmt.Sprintf("at least %d %ds in %d rolls", count, face, n),
+		test: func(rolls []int) bool {
+			seen := 0
+			for _, f := range rolls {
+				if f == face {
+					seen++
+				}
+			}
+			return seen >= count
+		},
+		exact: func(d Die) float64 {
+			if count <= 0 {
+				return 1
+			}
+			// dist[k] is the probability of k hits so far, the last
+			// entry holding count hits or more.
+			p := d.Prob(face)
+			dist := make([]float64, count+1)
+			dist[0] = 1
+			for i := 0; i < n; i++ {
+				dist[count] += dist[count-1] * p
+				for k := count - 1; k > 0; k-- {
+					dist[k] = dist[k]*(1-p) + dist[k-1]*p
+				}
+				dist[0] *= 1 - p
+			}
+			return dist[count]
+		},
+	}
+}
+
+// Predicate is the goal of n rolls passing test, named name. Its exact
+// probability can only be found by enumerating every sequence of rolls.
+func Predicate(name string, n int, test func(rolls []int) bool) Goal {
+	return Goal{Rolls: n, name: name, test: test}
+}
+
+// Exact is the probability that rolls of d meet g, by the dynamic program
+// of the goal or else by enumerating every sequence of rolls.
+func Exact(d Die, g Goal) (float64, error) {
+	if g.exact != nil {
+		return g.exact(d), nil
+	}
+	if float64(g.Rolls)*math.Log(float64(d.Faces())) > math.Log(maxOutcomes) {
+		return 0, fmt.Errorf("%w: %d^%d", ErrTooManyOutcomes, d.Faces(), g.Rolls)
+	}
+	rolls := make([]int, g.Rolls)
+	var enumerate func(i int, p float64) float64
+	enumerate = func(i int, p float64) float64 {
+		if p == 0 {
+			return 0
+		}
+		if i == len(rolls) {
+			if g.test(rolls) {
+				return p
+			}
+			return 0
+		}
+		total := 0.0
+		for f := 1; f <= d.Faces(); f++ {
+			rolls[i] = f
+			total += enumerate(i+1, p*d.Prob(f))
+		}
+		return total
+	}
+	return enumerate(0, 1), nil
+}
+
+// ParseGoal reads a goal written as a sequence of faces, "11111" for faces
+// of one digit or "10,12,1" for any faces, as "sum>=20 in 5" for
+// SumAtLeast, or as "count 6>=2 in 5" for AtLeast.
+func ParseGoal(s string) (Goal, error) {
+	s = strings.TrimSpace(s)
+	bad := func(err error) (Goal, error) {
+		return Goal{}, fmt.Errorf("dice: goal %q: %v", s, err)
+	}
+	if cond, rolls, ok := strings.Cut(s, " in "); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(rolls))
+		if err != nil || n < 1 {
+			return bad(errors.New("want a positive number of rolls"))
+		}
+		left, right, ok := strings.Cut(cond, ">=")
+		if !ok {
+			return bad(errors.New("want >="))
+		}
+		k, err := strconv.Atoi(strings.TrimSpace(right))
+		if err != nil {
+			return bad(err)
+		}
+		left = strings.TrimSpace(left)
+		if left == "sum" {
+			return SumAtLeast(n, k), nil
+		}
+		if face, ok := strings.CutPrefix(left, "count "); ok {
+			f, err := strconv.Atoi(strings.TrimSpace(face))
+			if err != nil {
+				return bad(err)
+			}
+			return AtLeast(n, k, f), nil
+		}
+		return bad(errors.New("want sum or count <face>"))
+	}
+
+	var faces []int
+	if strings.Contains(s, ",") {
+		for _, part := range strings.Split(s, ",") {
+			f, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return bad(err)
+			}
+			faces = append(faces, f)
+		}
+	} else {
+		for _, c := range s {
+			if c < '0' || c > '9' {
+				return bad(errors.New("want digits"))
+			}
+			faces = append(faces, int(c-'0'))
+		}
+	}
+	if len(faces) == 0 {
+		return bad(errors.New("empty sequence"))
+	}
+	return Sequence(faces...), nil
+}
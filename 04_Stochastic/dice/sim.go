@@ -0,0 +1,55 @@
+package dice
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Estimate is the outcome of a simulation: Hits of Trials met the goal.
+type Estimate struct {
+	Hits   int
+	Trials int
+}
+
+// P is the estimated probability.
+func (e Estimate) P() float64 {
+	if e.Trials == 0 {
+		return 0
+	}
+	return float64(e.Hits) / float64(e.Trials)
+}
+
+// Interval is the Wilson score interval of the probability at z standard
+// deviations, 1.96 for 95% confidence. Unlike P ± z·stderr it stays within
+// [0, 1] and is not empty when no trial, or every trial, hit.
+func (e Estimate) Interval(z float64) (float64, float64) {
+	if e.Trials == 0 {
+		return 0, 1
+	}
+	n := float64(e.Trials)
+	p := e.P()
+	center := (p + z*z/(2*n)) / (1 + z*z/n)
+	half := z / (1 + z*z/n) * math.Sqrt(p*(1-p)/n+z*z/(4*n*n))
+	return math.Max(0, center-half), math.Min(1, center+half)
+}
+
+func (e Estimate) String() string {
+	lo, hi := e.Interval(1.96)
+	return fmt.Sprintf("%f (95%% confidence interval %f to %f, %d of %d trials)", e.P(), lo, hi, e.Hits, e.Trials)
+}
+
+// Simulate rolls d for goal g numTrials times, drawing with r.
+func Simulate(d Die, g Goal, numTrials int, r *rand.Rand) Estimate {
+	rolls := make([]int, g.Rolls)
+	e := Estimate{Trials: numTrials}
+	for t := 0; t < numTrials; t++ {
+		for i := range rolls {
+			rolls[i] = d.Roll(r)
+		}
+		if g.Test(rolls) {
+			e.Hits++
+		}
+	}
+	return e
+}
@@ -1,104 +1,106 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"math"
-	"math/big"
+	"log"
 	"math/rand"
-	"strconv"
+	"os"
+	"text/tabwriter"
 	"time"
+
+	"./birthday"
+	"./dice"
 )
 
-func rollDie() int {
-	return rand.Intn(6) + 1
+func runSim(d dice.Die, goal dice.Goal, numTrials int, r *rand.Rand) {
+	fmt.Printf("With a %v, %s:\n", d, goal)
+	if p, err := dice.Exact(d, goal); err != nil {
+		fmt.Println("   ", err)
+	} else {
+		fmt.Printf("Actual probability = %f\n", p)
+	}
+	fmt.Println("Estimated probability =", dice.Simulate(d, goal, numTrials, r))
 }
 
-func runSim(goal string, numTrials int, txt string) {
-	total := 0
-	for i := 0; i < numTrials; i++ {
-		var result string
-		for j := 0; j < len(goal); j++ {
-			result += strconv.Itoa(rollDie())
-			if result == goal {
-				total++
+// allDifferent is the goal of n rolls showing no face twice.
+func allDifferent(n int) dice.Goal {
+	return dice.Predicate(fmt.Sprintf("no face twice in %d rolls", n), n, func(rolls []int) bool {
+		seen := make(map[int]bool)
+		for _, f := range rolls {
+			if seen[f] {
+				return false
 			}
+			seen[f] = true
 		}
-	}
-	fmt.Printf("Actual probability of %s = %f\n", txt, 1.0/math.Pow(6.0, float64(len(goal))))
-	estProbability := float64(total) / float64(numTrials)
-	fmt.Printf("Estimated probability of %s = %f\n", txt, estProbability)
+		return true
+	})
 }
 
-func makeRange(min, max int) []int {
-	r := make([]int, max-min)
-	for i := range r {
-		r[i] = min + i
+// birthdayProb prints the simulated and exact probability that at least
+// numSame of numPeople share a birthday, for each number of people.
+func birthdayProb(cal birthday.Calendar, people []int, numSame int, numTrials int, r *rand.Rand) {
+	fmt.Printf("Probability that at least %d share a birthday, %v:\n", numSame, cal)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "  people\tsimulated\texact")
+	for _, numPeople := range people {
+		exact, err := cal.Exact(numPeople, numSame)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		est := cal.Simulate(numPeople, numSame, numTrials, r)
+		fmt.Fprintf(tw, "  %d\t%f\t%s\n", numPeople, est, exact.Text('f', 6))
 	}
-	return r
+	tw.Flush()
 }
 
-func sameDate(numPeople int, numSame int) bool {
-	possibleDates := makeRange(0, 365)
-	/*
-		var possibleDates []int
-		r1 := makeRange(0, 57)
-		possibleDates = append(possibleDates, r1...)
-		possibleDates = append(possibleDates, r1...)
-		possibleDates = append(possibleDates, r1...)
-		possibleDates = append(possibleDates, r1...)
-		possibleDates = append(possibleDates, 58)
-		r2 := makeRange(59, 366)
-		possibleDates = append(possibleDates, r2...)
-		possibleDates = append(possibleDates, r2...)
-		possibleDates = append(possibleDates, r2...)
-		possibleDates = append(possibleDates, r2...)
-		r3 := makeRange(180, 270)
-		possibleDates = append(possibleDates, r3...)
-		possibleDates = append(possibleDates, r3...)
-		possibleDates = append(possibleDates, r3...)
-		possibleDates = append(possibleDates, r3...)
-	*/
-
-	birthdays := [366]int{}
-	for p := 0; p < numPeople; p++ {
-		birthDate := possibleDates[rand.Intn(len(possibleDates))]
-		birthdays[birthDate]++
-	}
-	max := 0
-	for _, b := range birthdays {
-		if max < b {
-			max = b
-		}
+func main() {
+	faces := flag.Int("faces", 6, "number of faces of the die")
+	weights := flag.String("weights", "", "load the die with these weights of its faces, e.g. 1,1,1,1,1,3")
+	goalFlag := flag.String("goal", "11111", "goal: a sequence like 11111 or 10,12,1, \"sum>=20 in 5\" or \"count 6>=2 in 5\"")
+	numTrials := flag.Int("trials", 1000000, "number of simulated trials of each goal")
+	days := flag.Int("days", 366, "number of equally likely days of the year")
+	calendarPath := flag.String("calendar", "", "read the weight of each day of the year from a file of day,weight lines")
+	numSame := flag.Int("same", 2, "number of people who must share a birthday")
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
 	}
-	return max >= numSame
-}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
 
-func birthdayProb(numPeople int, numSame int, numTrials int) float64 {
-	numHits := 0
-	for t := 0; t < numTrials; t++ {
-		if sameDate(numPeople, numSame) {
-			numHits++
+	var d dice.Die
+	var err error
+	if *weights != "" {
+		var w []float64
+		if w, err = dice.ParseWeights(*weights); err == nil {
+			err = d.InitWeighted(w)
 		}
+	} else {
+		err = d.Init(*faces)
 	}
-	return float64(numHits) / float64(numTrials)
-}
-
-func main() {
-	rand.Seed(time.Now().UTC().UnixNano())
-
-	runSim("11111", 1000000, "11111")
-
-	for _, numPeople := range [...]int{10, 20, 40, 100} {
-		fmt.Println("For", numPeople, "est. prob. of a shared birthday is", birthdayProb(numPeople, 2, 100000))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	goal, err := dice.ParseGoal(*goalFlag)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	runSim(d, goal, *numTrials, r)
+	for _, goal := range []dice.Goal{dice.SumAtLeast(5, 20), dice.AtLeast(5, 2, d.Faces()), allDifferent(5)} {
+		runSim(d, goal, *numTrials, r)
+	}
+	fmt.Println()
 
-		factorial366 := new(big.Int).MulRange(1, 366)
-		factorialN := new(big.Int).MulRange(1, int64(366-numPeople))
-		power366 := new(big.Int).Exp(big.NewInt(366), big.NewInt(int64(numPeople)), nil)
-		numerator := new(big.Float).SetInt(factorial366)
-		denomInt := new(big.Int).Mul(power366, factorialN)
-		denom := new(big.Float).SetInt(denomInt)
-		prob := new(big.Float).Sub(big.NewFloat(1.0), new(big.Float).Quo(numerator, denom))
-		//fmt.Println("Actual probability =", prob)
-		fmt.Println("Actual probability =", prob.String())
+	var cal birthday.Calendar
+	if *calendarPath != "" {
+		cal, err = birthday.LoadCalendar(*calendarPath)
+	} else {
+		err = cal.Init(*days)
+	}
+	if err != nil {
+		log.Fatalln(err)
 	}
+	birthdayProb(cal, []int{10, 20, 40, 100}, *numSame, 100000, r)
 }
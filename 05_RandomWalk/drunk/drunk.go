@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"math/rand"
 
+	"../../04_Stochastic/alias"
 	"../location"
 )
 
 type Drunk struct {
 	name        string
 	stepChoices []location.Location
+	weights     *alias.Table // nil when every step is as likely
 }
 
 func (d *Drunk) Name() string        { return d.name }
@@ -18,12 +20,34 @@ func (d *Drunk) String() string {
 	return fmt.Sprintf("name=%q, steps=%v", d.name, d.stepChoices)
 }
 
-func (d *Drunk) TakeStep() (float64, float64) {
-	n := rand.Intn(len(d.stepChoices))
+// TakeStep draws a step with r.
+func (d *Drunk) TakeStep(r *rand.Rand) (float64, float64) {
+	var n int
+	if d.weights != nil {
+		n = d.weights.Draw(r)
+	} else {
+		n = r.Intn(len(d.stepChoices))
+	}
 	step := d.stepChoices[n]
 	return step.X, step.Y
 }
 
 func (d *Drunk) SetStepChoices(steps []location.Location) {
 	d.stepChoices = steps[:]
+	d.weights = nil
+}
+
+// SetStepWeights makes the drunk take steps[i] in proportion to weights[i],
+// rather than repeating a step in the choices to make it more likely.
+func (d *Drunk) SetStepWeights(steps []location.Location, weights []float64) error {
+	if len(steps) != len(weights) {
+		return fmt.Errorf("drunk: %d steps and %d weights", len(steps), len(weights))
+	}
+	t, err := alias.New(weights)
+	if err != nil {
+		return fmt.Errorf("drunk: %w", err)
+	}
+	d.stepChoices = steps[:]
+	d.weights = t
+	return nil
 }
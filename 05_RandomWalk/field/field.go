@@ -36,12 +36,12 @@ func (f *Field) GetLoc(drunk drunk.Drunk) (location.Location, error) {
 	}
 }
 
-func (f *Field) MoveDrunk(drunk drunk.Drunk) error {
+func (f *Field) MoveDrunk(drunk drunk.Drunk, r *rand.Rand) error {
 	loc, ok := f.drunks[drunk.Name()]
 	if !ok {
 		return errors.New("moveDrunk: Drunk not in the field")
 	}
-	xDist, yDist := drunk.TakeStep()
+	xDist, yDist := drunk.TakeStep(r)
 	f.drunks[drunk.Name()] = loc.Move(xDist, yDist)
 
 	return nil
@@ -56,25 +56,25 @@ type OddField struct {
 
 func (f *OddField) Name() string        { return f.name }
 func (f *OddField) SetName(name string) { f.name = name }
-func (f *OddField) SetWormHoles(numHoles int, xRange int, yRange int) {
+func (f *OddField) SetWormHoles(numHoles int, xRange int, yRange int, r *rand.Rand) {
 	f.wormHoles = map[location.Location]location.Location{}
 	for w := 0; w < numHoles; w++ {
-		x := float64(rand.Intn(2*xRange) - xRange)
-		y := float64(rand.Intn(2*yRange) - yRange)
+		x := float64(r.Intn(2*xRange) - xRange)
+		y := float64(r.Intn(2*yRange) - yRange)
 		loc := location.Location{x, y}
-		newX := float64(rand.Intn(2*xRange) - xRange)
-		newY := float64(rand.Intn(2*yRange) - yRange)
+		newX := float64(r.Intn(2*xRange) - xRange)
+		newY := float64(r.Intn(2*yRange) - yRange)
 		newLoc := location.Location{newX, newY}
 		f.wormHoles[loc] = newLoc
 	}
 }
 
-func (f *OddField) MoveDrunk(drunk drunk.Drunk) error {
+func (f *OddField) MoveDrunk(drunk drunk.Drunk, r *rand.Rand) error {
 	loc, ok := f.drunks[drunk.Name()]
 	if !ok {
 		return errors.New("OddField moveDrunk: Drunk not in the field")
 	}
-	xDist, yDist := drunk.TakeStep()
+	xDist, yDist := drunk.TakeStep(r)
 	nextLoc := loc.Move(xDist, yDist)
 	newLoc, ok := f.wormHoles[nextLoc]
 	if !ok {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -17,6 +18,14 @@ import (
 )
 
 func main() {
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
+
 	steps := []location.Location{{0, 1}, {0, -1}, {1, 0}, {-1, 0}}
 	var usualDrunk drunk.Drunk
 	usualDrunk.SetName("usual")
@@ -29,18 +38,17 @@ func main() {
 
 	drunks := [...]drunk.Drunk{usualDrunk, masochistDrunk}
 
-	rand.Seed(time.Now().UTC().UnixNano())
-	plotLocs(drunks[:], 10000, 1000)
+	plotLocs(drunks[:], 10000, 1000, r)
 }
 
-func getFinalLocs(numSteps int, numTrials int, dClass drunk.Drunk) []location.Location {
+func getFinalLocs(numSteps int, numTrials int, dClass drunk.Drunk, r *rand.Rand) []location.Location {
 	var locs []location.Location
 	for t := 0; t < numTrials; t++ {
 		var origin location.Location
 		var f field.Field
 		f.AddDrunk(dClass, origin)
 		for s := 0; s < numSteps; s++ {
-			f.MoveDrunk(dClass)
+			f.MoveDrunk(dClass, r)
 		}
 		loc, err := f.GetLoc(dClass)
 		if err != nil {
@@ -52,7 +60,7 @@ func getFinalLocs(numSteps int, numTrials int, dClass drunk.Drunk) []location.Lo
 	return locs
 }
 
-func plotLocs(drunkKinds []drunk.Drunk, numSteps int, numTrials int) {
+func plotLocs(drunkKinds []drunk.Drunk, numSteps int, numTrials int, r *rand.Rand) {
 	p, err := plot.New()
 	if err != nil {
 		log.Fatalln("plot.New()", err)
@@ -69,7 +77,7 @@ func plotLocs(drunkKinds []drunk.Drunk, numSteps int, numTrials int) {
 	p.Add(plotter.NewGrid())
 
 	for d, dClass := range drunkKinds {
-		locs := getFinalLocs(numSteps, numTrials, dClass)
+		locs := getFinalLocs(numSteps, numTrials, dClass, r)
 		pts := make(plotter.XYs, len(locs))
 		sumX := 0.0
 		sumY := 0.0
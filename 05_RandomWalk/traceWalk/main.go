@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -16,7 +17,7 @@ import (
 	"../location"
 )
 
-func traceWalk(fieldKinds []field.OddField, numSteps int, xRange int, yRange int) {
+func traceWalk(fieldKinds []field.OddField, numSteps int, xRange int, yRange int, r *rand.Rand) {
 	p, err := plot.New()
 	if err != nil {
 		log.Fatalln("plot.New()", err)
@@ -42,7 +43,7 @@ func traceWalk(fieldKinds []field.OddField, numSteps int, xRange int, yRange int
 
 		var locs []location.Location
 		for s := 0; s < numSteps; s++ {
-			fClass.MoveDrunk(usualDrunk)
+			fClass.MoveDrunk(usualDrunk, r)
 			loc, err := fClass.GetLoc(usualDrunk)
 			if err != nil {
 				log.Fatalln("getLoc", err)
@@ -80,16 +81,23 @@ func traceWalk(fieldKinds []field.OddField, numSteps int, xRange int, yRange int
 }
 
 func main() {
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
+
 	var fields []field.OddField
 
 	var of field.OddField
 	of.SetName("Normal")
 	fields = append(fields, of)
 
-	of.SetWormHoles(1000, 100, 100)
+	of.SetWormHoles(1000, 100, 100, r)
 	of.SetName("Odd Field")
 	fields = append(fields, of)
 
-	rand.Seed(time.Now().UTC().UnixNano())
-	traceWalk(fields, 500, 100, 100)
+	traceWalk(fields, 500, 100, 100, r)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -17,14 +18,14 @@ import (
 )
 
 // functions
-func walk(f field.Field, d drunk.Drunk, numSteps int) float64 {
+func walk(f field.Field, d drunk.Drunk, numSteps int, r *rand.Rand) float64 {
 	start, err := f.GetLoc(d)
 	if err != nil {
 		log.Fatalln("error", err)
 		return 0.0
 	}
 	for s := 0; s < numSteps; s++ {
-		f.MoveDrunk(d)
+		f.MoveDrunk(d, r)
 	}
 	loc, err := f.GetLoc(d)
 	if err != nil {
@@ -34,20 +35,20 @@ func walk(f field.Field, d drunk.Drunk, numSteps int) float64 {
 	return start.DistFrom(loc)
 }
 
-func simWalks(numSteps int, numTrials int, dClass drunk.Drunk) []float64 {
+func simWalks(numSteps int, numTrials int, dClass drunk.Drunk, r *rand.Rand) []float64 {
 	var origin location.Location
 	var distances []float64
 	for t := 0; t < numTrials; t++ {
 		var f field.Field
 		f.AddDrunk(dClass, origin)
-		distances = append(distances, walk(f, dClass, numSteps))
+		distances = append(distances, walk(f, dClass, numSteps, r))
 	}
 	return distances
 }
 
-func drunkTest(walkLengths []int, numTrials int, dClass drunk.Drunk) {
+func drunkTest(walkLengths []int, numTrials int, dClass drunk.Drunk, r *rand.Rand) {
 	for _, numSteps := range walkLengths {
-		distances := simWalks(numSteps, numTrials, dClass)
+		distances := simWalks(numSteps, numTrials, dClass, r)
 		fmt.Println(dClass, "random walk of", numSteps, "steps")
 		sum := 0.0
 		min := 0.0
@@ -71,11 +72,11 @@ func drunkTest(walkLengths []int, numTrials int, dClass drunk.Drunk) {
 	}
 }
 
-func simDrunk(numTrials int, dClass drunk.Drunk, walkLengths []int) []float64 {
+func simDrunk(numTrials int, dClass drunk.Drunk, walkLengths []int, r *rand.Rand) []float64 {
 	var meanDistances []float64
 	for _, numSteps := range walkLengths {
 		fmt.Println("Start simulation of", numSteps, "steps")
-		trials := simWalks(numSteps, numTrials, dClass)
+		trials := simWalks(numSteps, numTrials, dClass, r)
 		sum := 0.0
 		for _, d := range trials {
 			sum += d
@@ -87,7 +88,7 @@ func simDrunk(numTrials int, dClass drunk.Drunk, walkLengths []int) []float64 {
 	return meanDistances
 }
 
-func simAll(drunkKinds []drunk.Drunk, walkLengths []int, numTrials int) {
+func simAll(drunkKinds []drunk.Drunk, walkLengths []int, numTrials int, r *rand.Rand) {
 	p, err := plot.New()
 	if err != nil {
 		log.Fatalln("plot.New()", err)
@@ -101,7 +102,7 @@ func simAll(drunkKinds []drunk.Drunk, walkLengths []int, numTrials int) {
 
 	for d, dClass := range drunkKinds {
 		fmt.Println("Start simulation of", dClass)
-		means := simDrunk(numTrials, dClass, walkLengths)
+		means := simDrunk(numTrials, dClass, walkLengths, r)
 		fmt.Println("means =", means)
 		pts := make(plotter.XYs, len(walkLengths))
 		for i, w := range walkLengths {
@@ -133,16 +134,22 @@ func simAll(drunkKinds []drunk.Drunk, walkLengths []int, numTrials int) {
 }
 
 func main() {
-	rand.Seed(time.Now().UTC().UnixNano())
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
 
-	test_sanity()
+	test_sanity(r)
 
-	test_walk()
+	test_walk(r)
 
-	test_plot_all()
+	test_plot_all(r)
 }
 
-func test_sanity() {
+func test_sanity(r *rand.Rand) {
 	p := location.Location{1.2, 2.3}
 	fmt.Println("p=", p)
 
@@ -165,13 +172,13 @@ func test_sanity() {
 	fmt.Println("add usual", f)
 	f.AddDrunk(masochistDrunk, origin)
 	fmt.Println("add masochist", f)
-	dist := walk(f, usualDrunk, 10000)
+	dist := walk(f, usualDrunk, 10000, r)
 	fmt.Println("distance=", dist)
-	dist = walk(f, masochistDrunk, 10000)
+	dist = walk(f, masochistDrunk, 10000, r)
 	fmt.Println("distance=", dist)
 }
 
-func test_walk() {
+func test_walk(r *rand.Rand) {
 	steps := []location.Location{{0, 1}, {0, -1}, {1, 0}, {-1, 0}}
 	var usualDrunk drunk.Drunk
 	usualDrunk.SetName("usual")
@@ -182,12 +189,20 @@ func test_walk() {
 	masochistDrunk.SetName("masochist")
 	masochistDrunk.SetStepChoices(steps)
 
+	steps = []location.Location{{0, 1}, {0, -1}, {1, 0}, {-1, 0}}
+	var northDrunk drunk.Drunk
+	northDrunk.SetName("north")
+	if err := northDrunk.SetStepWeights(steps, []float64{1.2, 0.8, 1, 1}); err != nil {
+		log.Fatalln(err)
+	}
+
 	testSteps := [...]int{1000, 10000}
-	drunkTest(testSteps[:], 100, usualDrunk)
-	drunkTest(testSteps[:], 100, masochistDrunk)
+	drunkTest(testSteps[:], 100, usualDrunk, r)
+	drunkTest(testSteps[:], 100, masochistDrunk, r)
+	drunkTest(testSteps[:], 100, northDrunk, r)
 }
 
-func test_plot_all() {
+func test_plot_all(r *rand.Rand) {
 	steps := []location.Location{{0, 1}, {0, -1}, {1, 0}, {-1, 0}}
 	var usualDrunk drunk.Drunk
 	usualDrunk.SetName("usual")
@@ -200,5 +215,5 @@ func test_plot_all() {
 
 	drunks := [...]drunk.Drunk{usualDrunk, masochistDrunk}
 	numSteps := [...]int{10, 100, 1000, 10000, 100000}
-	simAll(drunks[:], numSteps[:], 100)
+	simAll(drunks[:], numSteps[:], 100, r)
 }
@@ -1,26 +1,38 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"log"
 	"math"
+	"math/rand"
+	"time"
 
 	"./roulette"
 )
 
 func main() {
-	//rand.Seed(time.Now().UTC().UnixNano())
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
+
+	test_fair(r)
 
-	test_fair()
+	test_all(r)
 
-	test_all()
+	test_empirical(r)
 
-	test_empirical()
+	test_biased(r)
 }
 
-func playRoulette(game roulette.Roulette, numSpins int, pocket int, bet int, toPrint bool) float64 {
+func playRoulette(game roulette.Roulette, numSpins int, pocket int, bet int, toPrint bool, r *rand.Rand) float64 {
 	totPocket := 0
 	for i := 0; i < numSpins; i++ {
-		game.Spin()
+		game.Spin(r)
 		totPocket += game.BetPocket(pocket, bet)
 	}
 	expectReturn := float64(totPocket) / float64(numSpins)
@@ -31,28 +43,28 @@ func playRoulette(game roulette.Roulette, numSpins int, pocket int, bet int, toP
 	return expectReturn
 }
 
-func test_fair() {
+func test_fair(r *rand.Rand) {
 	var game roulette.Roulette
 	game.Init(roulette.Fair)
 
 	numSpins := [...]int{100, 1000000}
 	for _, spin := range numSpins {
 		for i := 0; i < 3; i++ {
-			playRoulette(game, spin, 2, 1, true)
+			playRoulette(game, spin, 2, 1, true, r)
 		}
 	}
 }
 
-func findPocketReturn(game roulette.Roulette, numTrials int, trialSize int, toPrint bool) []float64 {
+func findPocketReturn(game roulette.Roulette, numTrials int, trialSize int, toPrint bool, r *rand.Rand) []float64 {
 	var pocketReturns []float64
 	for i := 0; i < numTrials; i++ {
-		trivals := playRoulette(game, trialSize, 2, 1, toPrint)
+		trivals := playRoulette(game, trialSize, 2, 1, toPrint, r)
 		pocketReturns = append(pocketReturns, trivals)
 	}
 	return pocketReturns
 }
 
-func test_all() {
+func test_all(r *rand.Rand) {
 	numTrials := 20
 	var games []roulette.Roulette
 	var game roulette.Roulette
@@ -65,7 +77,7 @@ func test_all() {
 	for _, numSpins := range [...]int{1000, 10000, 100000, 1000000} {
 		fmt.Println("\nSimulate", numTrials, "trials of", numSpins, "spins each")
 		for _, game := range games {
-			pocketReturns := findPocketReturn(game, numTrials, numSpins, false)
+			pocketReturns := findPocketReturn(game, numTrials, numSpins, false, r)
 			sum := 0.0
 			for _, r := range pocketReturns {
 				sum += r
@@ -90,7 +102,7 @@ func getMeanAndStd(X []float64) (float64, float64) {
 	return mean, std
 }
 
-func test_empirical() {
+func test_empirical(r *rand.Rand) {
 	numTrials := 20
 	var games []roulette.Roulette
 	var game roulette.Roulette
@@ -103,9 +115,31 @@ func test_empirical() {
 	for _, numSpins := range [...]int{1000, 100000, 1000000} {
 		fmt.Println("\nSimulate betting a pocket for", numTrials, "trials of", numSpins, "spin each")
 		for _, game := range games {
-			pocketReturns := findPocketReturn(game, numTrials, numSpins, false)
+			pocketReturns := findPocketReturn(game, numTrials, numSpins, false, r)
 			mean, std := getMeanAndStd(pocketReturns)
 			fmt.Printf("Exp. return for %s = %.3f%%, +/- %.3f%% with 95%% confidence\n", game, 100.0*mean, 100.0*1.96*std)
 		}
 	}
 }
+
+// test_biased bets on a pocket of a fair wheel that lands there 10% more
+// often than in any other pocket.
+func test_biased(r *rand.Rand) {
+	var game roulette.Roulette
+	game.Init(roulette.Fair)
+	pocket := 2
+	var weights []float64
+	for _, p := range game.Pockets() {
+		if p == pocket {
+			weights = append(weights, 1.1)
+		} else {
+			weights = append(weights, 1)
+		}
+	}
+	if err := game.SetPocketWeights(weights); err != nil {
+		log.Fatalln(err)
+	}
+	prob := 1.1 / (float64(len(weights)) + 0.1)
+	fmt.Printf("\nBetting %d on a wheel biased toward it, expected return = %.4f%%\n", pocket, 100*(prob*36-1))
+	playRoulette(game, 1000000, pocket, 1, true, r)
+}
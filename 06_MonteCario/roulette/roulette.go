@@ -1,7 +1,10 @@
 package roulette
 
 import (
+	"fmt"
 	"math/rand"
+
+	"../../04_Stochastic/alias"
 )
 
 type RouletteType int
@@ -16,6 +19,7 @@ type Roulette struct {
 	rouletteType RouletteType
 	pocketOdd    int
 	pockets      []int
+	weights      *alias.Table // nil when the wheel is true
 	ball         int
 }
 
@@ -33,6 +37,7 @@ func (r Roulette) String() string {
 }
 
 func (r *Roulette) Init(rouletteType RouletteType) {
+	r.weights = nil
 	r.pockets = make([]int, 0)
 	for i := 1; i < 37; i++ {
 		r.pockets = append(r.pockets, i)
@@ -47,8 +52,31 @@ func (r *Roulette) Init(rouletteType RouletteType) {
 	}
 }
 
-func (r *Roulette) Spin() {
-	i := rand.Intn(len(r.pockets))
+// Pockets lists the pockets of the wheel: 1 to 36, then any zeros.
+func (r *Roulette) Pockets() []int { return r.pockets }
+
+// SetPocketWeights biases the wheel so that the ball lands in Pockets()[i]
+// in proportion to weights[i].
+func (r *Roulette) SetPocketWeights(weights []float64) error {
+	if len(weights) != len(r.pockets) {
+		return fmt.Errorf("roulette: %d weights for %d pockets", len(weights), len(r.pockets))
+	}
+	t, err := alias.New(weights)
+	if err != nil {
+		return fmt.Errorf("roulette: %w", err)
+	}
+	r.weights = t
+	return nil
+}
+
+// Spin draws the pocket the ball lands in with rnd.
+func (r *Roulette) Spin(rnd *rand.Rand) {
+	var i int
+	if r.weights != nil {
+		i = r.weights.Draw(rnd)
+	} else {
+		i = rnd.Intn(len(r.pockets))
+	}
 	r.ball = r.pockets[i]
 }
 
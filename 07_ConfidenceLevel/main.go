@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
 	"math/rand"
@@ -9,11 +10,11 @@ import (
 	"gonum.org/v1/gonum/stat"
 )
 
-func throwNeedles(numNeedles int) float64 {
+func throwNeedles(numNeedles int, r *rand.Rand) float64 {
 	inCircle := 0
 	for i := 0; i < numNeedles; i++ {
-		x := rand.Float64()
-		y := rand.Float64()
+		x := r.Float64()
+		y := r.Float64()
 		if math.Sqrt(x*x+y*y) <= 1.0 {
 			inCircle++
 		}
@@ -21,10 +22,10 @@ func throwNeedles(numNeedles int) float64 {
 	return 4.0 * (float64(inCircle) / float64(numNeedles))
 }
 
-func getEst(numNeedles int, numTrials int) (float64, float64) {
+func getEst(numNeedles int, numTrials int, r *rand.Rand) (float64, float64) {
 	var estimates []float64
 	for t := 0; t < numTrials; t++ {
-		piGuess := throwNeedles(numNeedles)
+		piGuess := throwNeedles(numNeedles, r)
 		estimates = append(estimates, piGuess)
 	}
 	sDev := stat.StdDev(estimates, nil)
@@ -38,19 +39,25 @@ func getEst(numNeedles int, numTrials int) (float64, float64) {
 	return curEst, sDev
 }
 
-func estPi(precision float64, numTrials int) float64 {
+func estPi(precision float64, numTrials int, r *rand.Rand) float64 {
 	numNeedles := 1000
 	sDev := precision
 	var curEst float64
 	for sDev >= precision/2 {
-		curEst, sDev = getEst(numNeedles, numTrials)
+		curEst, sDev = getEst(numNeedles, numTrials, r)
 		numNeedles *= 2
 	}
 	return curEst
 }
 
 func main() {
-	rand.Seed(time.Now().UTC().UnixNano())
-	//fmt.Println("pi =", throwNeedles(1000000))
-	estPi(0.005, 100)
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
+	//fmt.Println("pi =", throwNeedles(1000000, r))
+	estPi(0.005, 100, r)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
 	"math/rand"
@@ -11,7 +12,13 @@ import (
 )
 
 func main() {
-	rand.Seed(time.Now().UTC().UnixNano())
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
 
 	population := citytemp.GetHighs()
 	popMean := stat.Mean(population, nil)
@@ -22,7 +29,7 @@ func main() {
 	numBad := 0
 
 	for t := 0; t < numTrials; t++ {
-		sample := citytemp.Sampling(sampleSize)
+		sample := citytemp.Sampling(sampleSize, r)
 		sampleMean, std := stat.MeanStdDev(sample, nil)
 		stdErr := stat.StdErr(std, float64(sampleSize))
 		if math.Abs(popMean-sampleMean) > 1.96*stdErr {
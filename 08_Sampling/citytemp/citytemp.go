@@ -47,8 +47,9 @@ func GetHighs() []float64 {
 	return population
 }
 
-func Sampling(size int) []float64 {
-	rand.Shuffle(len(population), func(i, j int) {
+// Sampling draws size of the highs without replacement with r.
+func Sampling(size int, r *rand.Rand) []float64 {
+	r.Shuffle(len(population), func(i, j int) {
 		population[i], population[j] = population[j], population[i]
 	})
 	samples := population[:size]
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
@@ -20,14 +21,14 @@ type errPoints struct {
 	plotter.YErrors
 }
 
-func showErrorBars(population []float64, sizes []int, numTrials int) {
+func showErrorBars(population []float64, sizes []int, numTrials int, r *rand.Rand) {
 	var xVals []int
 	var sizeMeans, sizeSDs []float64
 	for _, sampleSize := range sizes {
 		xVals = append(xVals, sampleSize)
 		var trialMeans []float64
 		for t := 0; t < numTrials; t++ {
-			sample := citytemp.Sampling(sampleSize)
+			sample := citytemp.Sampling(sampleSize, r)
 			sampleMean := stat.Mean(sample, nil)
 			trialMeans = append(trialMeans, sampleMean)
 		}
@@ -88,10 +89,16 @@ func showErrorBars(population []float64, sizes []int, numTrials int) {
 }
 
 func main() {
-	rand.Seed(time.Now().UTC().UnixNano())
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
 
 	population := citytemp.GetHighs()
 
 	sampleSizes := [...]int{50, 100, 200, 300, 400, 500, 600}
-	showErrorBars(population, sampleSizes[:], 100)
+	showErrorBars(population, sampleSizes[:], 100, r)
 }
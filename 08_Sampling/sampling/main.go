@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math/rand"
 	"time"
@@ -13,7 +14,7 @@ import (
 	"gonum.org/v1/plot/vg"
 )
 
-func makeHist(data []float64, fileName, title, xLabel, yLabel string, bins int) {
+func makeHist(data []float64, fileName, title, xLabel, yLabel string, bins int, r *rand.Rand) {
 	v := make(plotter.Values, len(data))
 	for i := range v {
 		v[i] = data[i]
@@ -33,7 +34,7 @@ func makeHist(data []float64, fileName, title, xLabel, yLabel string, bins int)
 	if err != nil {
 		panic(err)
 	}
-	h.FillColor = plotutil.Color(rand.Intn(5))
+	h.FillColor = plotutil.Color(r.Intn(5))
 	//h.FillColor = color.RGBA{0, 0, 255, 255}
 	p.Add(h)
 
@@ -45,7 +46,13 @@ func makeHist(data []float64, fileName, title, xLabel, yLabel string, bins int)
 }
 
 func main() {
-	rand.Seed(time.Now().UTC().UnixNano())
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
 
 	population := citytemp.GetHighs()
 
@@ -56,9 +63,9 @@ func main() {
 	title := fmt.Sprintf("Daily High 1961-2015, Population\n(mean = %.2f)", mean)
 	xLabel := "Degrees C"
 	yLabel := "Number Days"
-	makeHist(population, "population.png", title, xLabel, yLabel, 20)
+	makeHist(population, "population.png", title, xLabel, yLabel, 20, r)
 
-	samples := citytemp.Sampling(100)
+	samples := citytemp.Sampling(100, r)
 	mean, std = stat.MeanStdDev(samples, nil)
 	fmt.Printf("Sample mean = %.2f\n", mean)
 	fmt.Printf("Standard deviation of sample = %.2f\n", std)
@@ -66,18 +73,18 @@ func main() {
 	title = fmt.Sprintf("Daily High 1961-2015, Sample\n(mean = %.2f)", mean)
 	xLabel = "Degrees C"
 	yLabel = "Number Days"
-	makeHist(samples, "sample100.png", title, xLabel, yLabel, 20)
+	makeHist(samples, "sample100.png", title, xLabel, yLabel, 20, r)
 
 	sampleSize := 100
 	numSamples := 1000
 	var sampleMeans []float64
 	for i := 0; i < numSamples; i++ {
-		sample := citytemp.Sampling(sampleSize)
+		sample := citytemp.Sampling(sampleSize, r)
 		mean := stat.Mean(sample, nil)
 		sampleMeans = append(sampleMeans, mean)
 	}
 	mean, std = stat.MeanStdDev(sampleMeans, nil)
 	fmt.Printf("Mean of sample means = %.2f\n", mean)
 	fmt.Printf("Standard deviation of sample means = %.2f\n", std)
-	makeHist(sampleMeans, "sampleAll.png", "Means of Samples", "Mean", "Frequency", 20)
+	makeHist(sampleMeans, "sampleAll.png", "Means of Samples", "Mean", "Frequency", 20, r)
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
@@ -16,7 +17,13 @@ import (
 )
 
 func main() {
-	rand.Seed(time.Now().UTC().UnixNano())
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
 
 	sampleSizes := [...]int{25, 50, 100, 200, 300, 400, 500, 600}
 	numTrials := 50
@@ -32,7 +39,7 @@ func main() {
 		sems = append(sems, sem)
 		var means []float64
 		for t := 0; t < numTrials; t++ {
-			sample := citytemp.Sampling(size)
+			sample := citytemp.Sampling(size, r)
 			mean := stat.Mean(sample, nil)
 			means = append(means, mean)
 		}
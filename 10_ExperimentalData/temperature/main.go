@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
@@ -115,12 +116,12 @@ func plot_data() {
 	}
 }
 
-func splitData(xVals, yVals []float64) (trainX, trainY, testX, testY []float64) {
+func splitData(xVals, yVals []float64, r *rand.Rand) (trainX, trainY, testX, testY []float64) {
 	var xs []int
 	for i := 0; i < len(xVals); i++ {
 		xs = append(xs, i)
 	}
-	rand.Shuffle(len(xs), func(i, j int) {
+	r.Shuffle(len(xs), func(i, j int) {
 		xs[i], xs[j] = xs[j], xs[i]
 	})
 	toTrain := make(map[int]bool)
@@ -143,7 +144,7 @@ func splitData(xVals, yVals []float64) (trainX, trainY, testX, testY []float64)
 	return trainX, trainY, testX, testY
 }
 
-func train_and_test() {
+func train_and_test(r *rand.Rand) {
 	data := getTempData()
 	years := getYearlyMeans(data)
 	sort.Slice(years, func(i, j int) bool {
@@ -164,7 +165,7 @@ func train_and_test() {
 	}
 
 	for f := 0; f < numSubsets; f++ {
-		trainX, trainY, testX, testY := splitData(xVals, yVals)
+		trainX, trainY, testX, testY := splitData(xVals, yVals, r)
 		for _, d := range dimensions {
 			model := polyRegression(trainX, trainY, d)
 			var estYVals []float64
@@ -189,11 +190,17 @@ func train_and_test() {
 }
 
 func main() {
-	rand.Seed(time.Now().UTC().UnixNano())
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
 
 	plot_data()
 
-	train_and_test()
+	train_and_test(r)
 }
 
 func Vandermonde(a []float64, degree int) *mat.Dense {
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/csv"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -68,16 +69,16 @@ func getData(toScale bool) (patients []cluster.Patient) {
 	return patients
 }
 
-func sampling(patients []cluster.Patient, size int) []cluster.Patient {
-	rand.Shuffle(len(patients), func(i, j int) {
+func sampling(patients []cluster.Patient, size int, r *rand.Rand) []cluster.Patient {
+	r.Shuffle(len(patients), func(i, j int) {
 		patients[i], patients[j] = patients[j], patients[i]
 	})
 	samples := patients[:size]
 	return samples
 }
 
-func kmeans(examples []cluster.Patient, k int, verbose bool) ([]cluster.Cluster, error) {
-	initialCentroids := sampling(examples, k)
+func kmeans(examples []cluster.Patient, k int, verbose bool, r *rand.Rand) ([]cluster.Cluster, error) {
+	initialCentroids := sampling(examples, k, r)
 	//fmt.Println("initialCentroids =", initialCentroids)
 	var clusters []cluster.Cluster
 	for _, e := range initialCentroids {
@@ -139,8 +140,8 @@ func kmeans(examples []cluster.Patient, k int, verbose bool) ([]cluster.Cluster,
 	return clusters, nil
 }
 
-func trykmeans(examples []cluster.Patient, numClusters int, numTrials int, verbose bool) []cluster.Cluster {
-	best, err := kmeans(examples, numClusters, verbose)
+func trykmeans(examples []cluster.Patient, numClusters int, numTrials int, verbose bool, r *rand.Rand) []cluster.Cluster {
+	best, err := kmeans(examples, numClusters, verbose, r)
 	if err != nil {
 		panic(err)
 		return best
@@ -148,7 +149,7 @@ func trykmeans(examples []cluster.Patient, numClusters int, numTrials int, verbo
 	minDissimilarity := cluster.Dissimilarity(best)
 	trial := 1
 	for trial < numTrials {
-		clusters, err := kmeans(examples, numClusters, verbose)
+		clusters, err := kmeans(examples, numClusters, verbose, r)
 		if err != nil {
 			continue
 		}
@@ -180,14 +181,20 @@ func printClustering(clustering []cluster.Cluster) []float64 {
 	return posFracs
 }
 
-func testClustering(patients []cluster.Patient, numClusters int, numTrials int) []float64 {
-	bestClustering := trykmeans(patients, numClusters, numTrials, false)
+func testClustering(patients []cluster.Patient, numClusters int, numTrials int, r *rand.Rand) []float64 {
+	bestClustering := trykmeans(patients, numClusters, numTrials, false, r)
 	posFracs := printClustering(bestClustering)
 	return posFracs
 }
 
 func main() {
-	rand.Seed(time.Now().UTC().UnixNano())
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
 
 	//patients := getData(false)
 	//fmt.Println(patients)
@@ -197,6 +204,6 @@ func main() {
 	numClusters := [...]int{2, 4, 6}
 	for _, k := range numClusters {
 		fmt.Printf("\nTest k-means (k = %d)\n", k)
-		testClustering(patients, k, 2)
+		testClustering(patients, k, 2, r)
 	}
 }
@@ -1,13 +1,20 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math/rand"
 	"time"
 )
 
 func main() {
-	rand.Seed(time.Now().UTC().UnixNano())
+	seed := flag.Int64("seed", 0, "seed of the random numbers, 0 to seed from the clock")
+	flag.Parse()
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+	fmt.Println("seed =", *seed)
+	r := rand.New(rand.NewSource(*seed))
 
 	numCasesPerYear := 36000
 	numYears := 3
@@ -20,7 +27,7 @@ func main() {
 	for t := 0; t < numTrials; t++ {
 		locs := make([]int, numCommunities)
 		for i := 0; i < numYears*numCasesPerYear; i++ {
-			locs[rand.Intn(numCommunities)]++
+			locs[r.Intn(numCommunities)]++
 		}
 		max := 0
 		for _, n := range locs {